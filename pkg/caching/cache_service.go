@@ -9,6 +9,9 @@ import (
 type CacheService interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 
+	// SetNX writes key only if it doesn't exist yet and reports whether it did
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+
 	Get(ctx context.Context, key string) (string, error)
 
 	Delete(ctx context.Context, key string) error
@@ -18,4 +21,27 @@ type CacheService interface {
 	Exists(ctx context.Context, key string) (bool, error)
 
 	Subscribe(ctx context.Context, channel string) *redis.PubSub
+
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+
+	// HSet writes the given fields of a hash
+	HSet(ctx context.Context, key string, values map[string]interface{}) error
+
+	// HSetNX writes only the given fields of a hash that don't exist yet
+	HSetNX(ctx context.Context, key string, values map[string]interface{}) error
+
+	// HMGet returns the requested fields of a hash; fields that don't exist are absent from the result
+	HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error)
+
+	HDel(ctx context.Context, key string, fields ...string) error
+
+	ZIncrBy(ctx context.Context, key string, increment float64, member string) error
+
+	ZAdd(ctx context.Context, key string, members ...redis.Z) error
+
+	// ZUnionStore overwrites dest with the weighted sum of the given sorted sets
+	ZUnionStore(ctx context.Context, dest string, keys []string, weights []float64) error
+
+	// ZRevRangeWithScores returns members from highest to lowest score, stop is inclusive
+	ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error)
 }
@@ -0,0 +1,134 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimit allows at most Limit hits within any Window
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ErrOverLimit is returned by AllowN when n is more than a limit allows at all, so no wait would let the hits through
+var ErrOverLimit = errors.New("hits exceed the rate limit")
+
+// RateLimitResult is the outcome of RateLimiter.Allow
+type RateLimitResult struct {
+	Allowed bool
+	// RetryAfter is how long until the hit would be allowed; zero when Allowed
+	RetryAfter time.Duration
+	// Exceeded is the limit that refused the hit, the one with the longest wait if several did
+	Exceeded *RateLimit
+}
+
+type RateLimiter interface {
+	// Allow records a hit for key if it fits every limit. A refused hit is not recorded,
+	// so retrying too early doesn't push the wait further out.
+	Allow(ctx context.Context, key string, limits ...RateLimit) (RateLimitResult, error)
+	// AllowN is Allow for n hits at once, all recorded or none. Returns ErrOverLimit if n exceeds any of the limits.
+	AllowN(ctx context.Context, key string, n int, limits ...RateLimit) (RateLimitResult, error)
+}
+
+// slidingWindowScript keeps one sorted set of hit timestamps per limit and checks them all before recording,
+// so a hit refused by the daily limit doesn't eat into the per-minute one.
+// KEYS: one set per limit. ARGV: now in ms, member prefix, number of hits, then limit and window in ms for every key.
+// Returns {allowed, retry after in ms, index of the refusing limit}.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local n = tonumber(ARGV[3])
+local retry, refused = 0, 0
+for i, key in ipairs(KEYS) do
+	local limit = tonumber(ARGV[2 + 2 * i])
+	local window = tonumber(ARGV[3 + 2 * i])
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+	local count = redis.call('ZCARD', key)
+	if count + n > limit then
+		-- the hits fit once the oldest count + n - limit have left the window
+		local oldest = redis.call('ZRANGE', key, count + n - limit - 1, count + n - limit - 1, 'WITHSCORES')
+		local wait = tonumber(oldest[2]) + window - now
+		if wait < 1 then wait = 1 end
+		if wait > retry then
+			retry, refused = wait, i
+		end
+	end
+end
+if retry > 0 then
+	return {0, retry, refused}
+end
+for i, key in ipairs(KEYS) do
+	for j = 1, n do
+		redis.call('ZADD', key, now, ARGV[2] .. ':' .. j)
+	end
+	redis.call('PEXPIRE', key, tonumber(ARGV[3 + 2 * i]))
+end
+return {1, 0, 0}
+`)
+
+// RedisRateLimiter is a sliding-window log RateLimiter. Each hit is kept for the length of the window,
+// so limits are exact rather than reset at fixed boundaries.
+type RedisRateLimiter struct {
+	client *redis.Client
+	prefix string
+	logger *logrus.Logger
+}
+
+// NewRedisRateLimiter creates a RedisRateLimiter on the connection of r. Keys are stored under prefix.
+func NewRedisRateLimiter(r *RedisService, prefix string) *RedisRateLimiter {
+	return &RedisRateLimiter{client: r.client, prefix: prefix, logger: logging.GetLogger()}
+}
+
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limits ...RateLimit) (RateLimitResult, error) {
+	return l.AllowN(ctx, key, 1, limits...)
+}
+
+func (l *RedisRateLimiter) AllowN(ctx context.Context, key string, n int, limits ...RateLimit) (RateLimitResult, error) {
+	if n <= 0 {
+		return RateLimitResult{Allowed: true}, nil
+	}
+
+	keys := make([]string, 0, len(limits))
+	active := make([]RateLimit, 0, len(limits))
+	args := []interface{}{time.Now().UnixMilli(), uuid.NewString(), n}
+	for _, limit := range limits {
+		if limit.Limit <= 0 || limit.Window <= 0 {
+			continue // disabled
+		}
+		if n > limit.Limit {
+			return RateLimitResult{}, fmt.Errorf("%w: %d hits can never fit a limit of %d", ErrOverLimit, n, limit.Limit)
+		}
+		active = append(active, limit)
+		keys = append(keys, l.prefix+key+":"+strconv.FormatInt(limit.Window.Milliseconds(), 10))
+		args = append(args, limit.Limit, limit.Window.Milliseconds())
+	}
+	if len(keys) == 0 {
+		return RateLimitResult{Allowed: true}, nil
+	}
+
+	res, err := slidingWindowScript.Run(ctx, l.client, keys, args...).Int64Slice()
+	if err != nil {
+		l.logger.Errorf("Redis rate limit error for key=%s: %v", key, err)
+		return RateLimitResult{}, err
+	}
+	if len(res) != 3 {
+		return RateLimitResult{}, fmt.Errorf("unexpected rate limit reply: %v", res)
+	}
+
+	if res[0] == 1 {
+		return RateLimitResult{Allowed: true}, nil
+	}
+	l.logger.Debugf("Redis rate limit exceeded for key=%s", key)
+	return RateLimitResult{
+		RetryAfter: time.Duration(res[1]) * time.Millisecond,
+		Exceeded:   &active[res[2]-1],
+	}, nil
+}
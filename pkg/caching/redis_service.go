@@ -53,6 +53,16 @@ func (c *RedisService) Set(ctx context.Context, key string, value interface{}, e
 	return nil
 }
 
+func (c *RedisService) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	ok, err := c.client.SetNX(ctx, key, value, expiration).Result()
+	if err != nil {
+		c.logger.Errorf("Redis SETNX error for key=%s: %v", key, err)
+		return false, err
+	}
+	c.logger.Debugf("Redis SETNX key=%s set=%t (exp=%s)", key, ok, expiration)
+	return ok, nil
+}
+
 func (c *RedisService) Get(ctx context.Context, key string) (string, error) {
 	val, err := c.client.Get(ctx, key).Result()
 	if err == redis.Nil {
@@ -98,6 +108,102 @@ func (c *RedisService) Subscribe(ctx context.Context, channel string) *redis.Pub
 	return c.client.Subscribe(ctx, channel)
 }
 
+func (c *RedisService) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	if err := c.client.Expire(ctx, key, expiration).Err(); err != nil {
+		c.logger.Errorf("Redis EXPIRE error for key=%s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+func (c *RedisService) HSet(ctx context.Context, key string, values map[string]interface{}) error {
+	if err := c.client.HSet(ctx, key, values).Err(); err != nil {
+		c.logger.Errorf("Redis HSET error for key=%s: %v", key, err)
+		return err
+	}
+	c.logger.Debugf("Redis HSET key=%s fields=%d", key, len(values))
+	return nil
+}
+
+func (c *RedisService) HSetNX(ctx context.Context, key string, values map[string]interface{}) error {
+	pipe := c.client.Pipeline()
+	for field, value := range values {
+		pipe.HSetNX(ctx, key, field, value)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logger.Errorf("Redis HSETNX error for key=%s: %v", key, err)
+		return err
+	}
+	c.logger.Debugf("Redis HSETNX key=%s fields=%d", key, len(values))
+	return nil
+}
+
+func (c *RedisService) HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error) {
+	vals, err := c.client.HMGet(ctx, key, fields...).Result()
+	if err != nil {
+		c.logger.Errorf("Redis HMGET error for key=%s: %v", key, err)
+		return nil, err
+	}
+
+	res := make(map[string]string, len(fields))
+	for i, v := range vals {
+		if s, ok := v.(string); ok {
+			res[fields[i]] = s
+		}
+	}
+	c.logger.Debugf("Redis HMGET key=%s hits=%d/%d", key, len(res), len(fields))
+	return res, nil
+}
+
+func (c *RedisService) HDel(ctx context.Context, key string, fields ...string) error {
+	if err := c.client.HDel(ctx, key, fields...).Err(); err != nil {
+		c.logger.Errorf("Redis HDEL error for key=%s: %v", key, err)
+		return err
+	}
+	c.logger.Debugf("Redis HDEL key=%s fields=%v", key, fields)
+	return nil
+}
+
+func (c *RedisService) ZIncrBy(ctx context.Context, key string, increment float64, member string) error {
+	if err := c.client.ZIncrBy(ctx, key, increment, member).Err(); err != nil {
+		c.logger.Errorf("Redis ZINCRBY error for key=%s: %v", key, err)
+		return err
+	}
+	c.logger.Debugf("Redis ZINCRBY key=%s member=%s by=%g", key, member, increment)
+	return nil
+}
+
+func (c *RedisService) ZAdd(ctx context.Context, key string, members ...redis.Z) error {
+	if len(members) == 0 {
+		return nil
+	}
+	if err := c.client.ZAdd(ctx, key, members...).Err(); err != nil {
+		c.logger.Errorf("Redis ZADD error for key=%s: %v", key, err)
+		return err
+	}
+	c.logger.Debugf("Redis ZADD key=%s members=%d", key, len(members))
+	return nil
+}
+
+func (c *RedisService) ZUnionStore(ctx context.Context, dest string, keys []string, weights []float64) error {
+	store := &redis.ZStore{Keys: keys, Weights: weights, Aggregate: "SUM"}
+	if err := c.client.ZUnionStore(ctx, dest, store).Err(); err != nil {
+		c.logger.Errorf("Redis ZUNIONSTORE error for dest=%s: %v", dest, err)
+		return err
+	}
+	c.logger.Debugf("Redis ZUNIONSTORE dest=%s keys=%d", dest, len(keys))
+	return nil
+}
+
+func (c *RedisService) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	res, err := c.client.ZRevRangeWithScores(ctx, key, start, stop).Result()
+	if err != nil {
+		c.logger.Errorf("Redis ZREVRANGE error for key=%s: %v", key, err)
+		return nil, err
+	}
+	return res, nil
+}
+
 // Close gracefully closes Redis connection
 func (c *RedisService) Close() error {
 	if err := c.client.Close(); err != nil {
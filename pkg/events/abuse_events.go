@@ -0,0 +1,16 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	TopicAbuseSuspected = "abuse.suspected"
+)
+
+// AbuseSuspectedPayload is sent when a user goes over a rate limit, at most once a day per user and action.
+type AbuseSuspectedPayload struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Action        string    `json:"action"` // "follow" or "like"
+	Limit         int       `json:"limit"`
+	WindowSeconds int64     `json:"window_seconds"`
+	DetectedAt    int64     `json:"detected_at_unix"`
+}
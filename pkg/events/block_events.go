@@ -0,0 +1,20 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	TopicUserBlocked   = "user.blocked"
+	TopicUserUnblocked = "user.unblocked"
+)
+
+type UserBlockedPayload struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+	CreatedAt int64     `json:"created_at_unix"`
+}
+
+type UserUnblockedPayload struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+	DeletedAt int64     `json:"deleted_at_unix"`
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"reflect"
+	"sort"
+)
+
+// CatalogEntry describes a known event type: the payload struct it carries
+// and the schema versions of that payload consumers can decode
+type CatalogEntry struct {
+	Type     string
+	Payload  reflect.Type
+	Versions []int
+}
+
+// Supports reports whether version is one of the entry's versions
+func (e CatalogEntry) Supports(version int) bool {
+	for _, v := range e.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// catalog lists every event the services exchange. Adding a payload version means adding it here
+// once the struct can decode it.
+var catalog = newCatalog(
+	entry[SubscriptionCreatedPayload](TopicSubscriptionCreated, 1),
+	entry[SubscriptionDeletedPayload](TopicSubscriptionDeleted, 1),
+	entry[SubscriptionRequestedPayload](TopicSubscriptionRequested, 1),
+	entry[SubscriptionApprovedPayload](TopicSubscriptionApproved, 1),
+
+	entry[LikeEvent](TopicLikeCreated, 1),
+	entry[LikeEvent](TopicLikeUpdated, 1),
+	entry[LikeEvent](TopicLikeDeleted, 1),
+
+	entry[UserBlockedPayload](TopicUserBlocked, 1),
+	entry[UserUnblockedPayload](TopicUserUnblocked, 1),
+	entry[MuteChangedPayload](TopicMuteChanged, 1),
+
+	entry[ListMemberAddedPayload](TopicListMemberAdded, 1),
+	entry[ListMemberRemovedPayload](TopicListMemberRemoved, 1),
+	entry[ListDeletedPayload](TopicListDeleted, 1),
+
+	entry[AbuseSuspectedPayload](TopicAbuseSuspected, 1),
+
+	entry[UserCreatedPayload](TopicUserCreated, 1),
+)
+
+func entry[T any](eventType string, versions ...int) CatalogEntry {
+	return CatalogEntry{Type: eventType, Payload: reflect.TypeOf((*T)(nil)).Elem(), Versions: versions}
+}
+
+func newCatalog(entries ...CatalogEntry) map[string]CatalogEntry {
+	m := make(map[string]CatalogEntry, len(entries))
+	for _, e := range entries {
+		m[e.Type] = e
+	}
+	return m
+}
+
+// Lookup returns the catalog entry of eventType
+func Lookup(eventType string) (CatalogEntry, bool) {
+	e, ok := catalog[eventType]
+	return e, ok
+}
+
+// Catalog returns all known event types, sorted by type
+func Catalog() []CatalogEntry {
+	entries := make([]CatalogEntry, 0, len(catalog))
+	for _, e := range catalog {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Type < entries[j].Type })
+	return entries
+}
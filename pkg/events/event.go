@@ -1,8 +1,105 @@
 package events
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// DefaultVersion is the schema version of payloads that don't state one, including all legacy messages
+const DefaultVersion = 1
+
+// ErrNotAnEvent is returned by Decode for a body that is neither an envelope nor, lacking a type, a raw payload
+var ErrNotAnEvent = errors.New("message is not an event envelope")
+
+// Event is the envelope every producer wraps payloads in.
+//
+// Two legacy formats are still decoded: {"type", "data"} without the metadata, as older Kafka producers
+// and the Keycloak listener send it, and a bare payload whose type is the RabbitMQ routing key.
 type Event struct {
-	Type string          `json:"type"`
-	Data json.RawMessage `json:"data"`
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`                  // schema version of Data
+	OccurredAt    time.Time       `json:"occurred_at"`              // when the change happened, not when it was sent
+	Source        string          `json:"source"`                   // producing service
+	CorrelationID string          `json:"correlation_id,omitempty"` // shared by the events of one request or flow
+	Subject       string          `json:"subject,omitempty"`        // entity the event is about, usually the partition key
+	Data          json.RawMessage `json:"data"`
+}
+
+// New wraps data in an envelope with a fresh ID, the current time and the default version.
+// The correlation ID is taken from ctx, or else the event starts a flow of its own.
+func New(ctx context.Context, eventType, source string, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("marshal event data: %w", err)
+	}
+
+	id := uuid.NewString()
+	correlationID := CorrelationID(ctx)
+	if correlationID == "" {
+		correlationID = id
+	}
+	return Event{
+		ID:            id,
+		Type:          eventType,
+		Version:       DefaultVersion,
+		OccurredAt:    time.Now().UTC(),
+		Source:        source,
+		CorrelationID: correlationID,
+		Data:          raw,
+	}, nil
+}
+
+// Legacy reports whether the event was decoded from one of the legacy formats
+func (e Event) Legacy() bool {
+	return e.ID == ""
+}
+
+// Decode reads an envelope in the current or a legacy format. eventType is what the transport
+// says the event is, e.g. the routing key; a body that isn't an envelope is taken as the raw payload
+// of that type, or rejected with ErrNotAnEvent if eventType is empty.
+func Decode(body []byte, eventType string) (Event, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil && eventType == "" {
+		return Event{}, fmt.Errorf("decode event: %w", err)
+	}
+
+	_, hasType := fields["type"]
+	_, hasData := fields["data"]
+	if !hasType || !hasData {
+		if eventType == "" {
+			return Event{}, ErrNotAnEvent
+		}
+		return Event{Type: eventType, Version: DefaultVersion, Data: json.RawMessage(body)}, nil
+	}
+
+	var e Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		return Event{}, fmt.Errorf("decode event: %w", err)
+	}
+	if e.Type == "" {
+		e.Type = eventType
+	}
+	if e.Version == 0 {
+		e.Version = DefaultVersion
+	}
+	return e, nil
+}
+
+type correlationKey struct{}
+
+// WithCorrelationID returns a context whose produced events carry id as their correlation ID
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation ID stored in ctx, or ""
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
 }
@@ -0,0 +1,17 @@
+package events
+
+import "github.com/google/uuid"
+
+// Published by the Keycloak event listener, which predates the dotted naming
+const (
+	TopicUserCreated = "UserCreated"
+)
+
+// UserCreatedPayload is sent when an account registers or an admin creates one.
+// Names missing in Keycloak arrive as the string "null".
+type UserCreatedPayload struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"firstname"`
+	LastName  string    `json:"lastname"`
+}
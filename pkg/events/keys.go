@@ -0,0 +1,27 @@
+package events
+
+// Keyed payloads name the entity whose events must stay in order. The outbox stores the key
+// and the relay publishes with it, so those events land on one partition.
+type Keyed interface {
+	PartitionKey() string
+}
+
+// Subscription events are keyed by the follower, so a follow and its unfollow can't swap places
+func (p SubscriptionCreatedPayload) PartitionKey() string   { return p.FollowerID.String() }
+func (p SubscriptionDeletedPayload) PartitionKey() string   { return p.FollowerID.String() }
+func (p SubscriptionRequestedPayload) PartitionKey() string { return p.FollowerID.String() }
+func (p SubscriptionApprovedPayload) PartitionKey() string  { return p.FollowerID.String() }
+
+// Like events are keyed by the liked post or comment, which is what counting consumers aggregate on
+func (e LikeEvent) PartitionKey() string { return e.TargetID.String() }
+
+func (p UserBlockedPayload) PartitionKey() string   { return p.BlockerID.String() }
+func (p UserUnblockedPayload) PartitionKey() string { return p.BlockerID.String() }
+
+func (p MuteChangedPayload) PartitionKey() string { return p.MuterID.String() }
+
+func (p ListMemberAddedPayload) PartitionKey() string   { return p.ListID.String() }
+func (p ListMemberRemovedPayload) PartitionKey() string { return p.ListID.String() }
+func (p ListDeletedPayload) PartitionKey() string       { return p.ListID.String() }
+
+func (p AbuseSuspectedPayload) PartitionKey() string { return p.UserID.String() }
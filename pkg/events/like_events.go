@@ -0,0 +1,24 @@
+package events
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	TopicLikeCreated = "like.created"
+	TopicLikeUpdated = "like.updated" // reaction changed in place
+	TopicLikeDeleted = "like.deleted"
+)
+
+type LikeEvent struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	TargetType string    `json:"target_type"`
+	TargetID   uuid.UUID `json:"target_id"`
+	// PostID repeats TargetID for post likes, so consumers written before likes were polymorphic keep working
+	PostID    *uuid.UUID `json:"post_id,omitempty"`
+	Reaction  string     `json:"reaction"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // set on like.deleted
+}
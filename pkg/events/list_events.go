@@ -0,0 +1,31 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	TopicListMemberAdded   = "list.member_added"
+	TopicListMemberRemoved = "list.member_removed"
+	TopicListDeleted       = "list.deleted"
+)
+
+type ListMemberAddedPayload struct {
+	ListID   uuid.UUID `json:"list_id"`
+	OwnerID  uuid.UUID `json:"owner_id"`
+	MemberID uuid.UUID `json:"member_id"`
+	Kind     string    `json:"kind"`
+	AddedAt  int64     `json:"added_at_unix"`
+}
+
+type ListMemberRemovedPayload struct {
+	ListID    uuid.UUID `json:"list_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	MemberID  uuid.UUID `json:"member_id"`
+	Kind      string    `json:"kind"`
+	RemovedAt int64     `json:"removed_at_unix"`
+}
+
+type ListDeletedPayload struct {
+	ListID    uuid.UUID `json:"list_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	DeletedAt int64     `json:"deleted_at_unix"`
+}
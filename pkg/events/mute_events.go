@@ -0,0 +1,16 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	TopicMuteChanged = "mute.changed"
+)
+
+// MuteChangedPayload is sent when a mute starts, is extended, is lifted or expires.
+type MuteChangedPayload struct {
+	MuterID   uuid.UUID `json:"muter_id"`
+	MutedID   uuid.UUID `json:"muted_id"`
+	Muted     bool      `json:"muted"`
+	ExpiresAt *int64    `json:"expires_at_unix,omitempty"` // set for timed mutes
+	ChangedAt int64     `json:"changed_at_unix"`
+}
@@ -0,0 +1,131 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+var (
+	ErrNoHandler          = errors.New("no handler registered for event type")
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+	ErrInvalidPayload     = errors.New("invalid event payload")
+)
+
+// UnsupportedVersionError is returned for an event whose schema version no handler was registered for
+type UnsupportedVersionError struct {
+	Type      string
+	Version   int
+	Supported []int
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("event %s has version %d, supported versions are %v", e.Type, e.Version, e.Supported)
+}
+
+func (e *UnsupportedVersionError) Unwrap() error {
+	return ErrUnsupportedVersion
+}
+
+// Permanent reports whether handling failed because of the event itself, so retrying it can't help
+func Permanent(err error) bool {
+	return errors.Is(err, ErrInvalidPayload) || errors.Is(err, ErrUnsupportedVersion)
+}
+
+// Registry dispatches events to typed handlers. Handlers are registered with On before consuming starts.
+type Registry struct {
+	handlers map[string]registration
+}
+
+type registration struct {
+	versions []int
+	handle   func(ctx context.Context, e Event) error
+}
+
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]registration)}
+}
+
+// On registers handler for eventType. The payload is decoded into T and validated if T is a Validator.
+// versions are the payload versions handler understands; by default those of the catalog, or DefaultVersion
+// for types not in it. Registering a T other than the catalog's payload for eventType panics.
+func On[T any](r *Registry, eventType string, handler func(ctx context.Context, payload T) error, versions ...int) {
+	if entry, ok := Lookup(eventType); ok {
+		if t := reflect.TypeOf((*T)(nil)).Elem(); t != entry.Payload {
+			panic(fmt.Sprintf("events: %s carries %s, not %s", eventType, entry.Payload, t))
+		}
+		if len(versions) == 0 {
+			versions = entry.Versions
+		}
+	}
+	if len(versions) == 0 {
+		versions = []int{DefaultVersion}
+	}
+
+	r.handlers[eventType] = registration{
+		versions: versions,
+		handle: func(ctx context.Context, e Event) error {
+			var payload T
+			if err := json.Unmarshal(e.Data, &payload); err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidPayload, e.Type, err)
+			}
+			if v, ok := any(payload).(Validator); ok {
+				if err := v.Validate(); err != nil {
+					return fmt.Errorf("%w: %s: %v", ErrInvalidPayload, e.Type, err)
+				}
+			}
+			return handler(ctx, payload)
+		},
+	}
+}
+
+// Handle passes e to the handler of its type. The handler's context carries the envelope,
+// see FromContext, and its correlation ID, so events produced while handling continue the flow.
+func (r *Registry) Handle(ctx context.Context, e Event) error {
+	reg, ok := r.handlers[e.Type]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoHandler, e.Type)
+	}
+
+	version := e.Version
+	if version == 0 {
+		version = DefaultVersion
+	}
+	supported := false
+	for _, v := range reg.versions {
+		if v == version {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return &UnsupportedVersionError{Type: e.Type, Version: version, Supported: reg.versions}
+	}
+
+	ctx = context.WithValue(ctx, eventKey{}, e)
+	if e.CorrelationID != "" {
+		ctx = WithCorrelationID(ctx, e.CorrelationID)
+	}
+	return reg.handle(ctx, e)
+}
+
+// Types returns the event types with a handler, sorted
+func (r *Registry) Types() []string {
+	types := make([]string, 0, len(r.handlers))
+	for t := range r.handlers {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+type eventKey struct{}
+
+// FromContext returns the envelope of the event a handler was called for
+func FromContext(ctx context.Context) (Event, bool) {
+	e, ok := ctx.Value(eventKey{}).(Event)
+	return e, ok
+}
@@ -0,0 +1,36 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	TopicSubscriptionCreated = "subscription.created"
+	TopicSubscriptionDeleted = "subscription.deleted"
+
+	// A follow on a private account starts as a request and becomes a subscription once approved
+	TopicSubscriptionRequested = "subscription.requested"
+	TopicSubscriptionApproved  = "subscription.approved"
+)
+
+type SubscriptionCreatedPayload struct {
+	FollowerID uuid.UUID `json:"follower_id"`
+	FolloweeID uuid.UUID `json:"followee_id"`
+	CreatedAt  int64     `json:"created_at_unix"`
+}
+
+type SubscriptionDeletedPayload struct {
+	FollowerID uuid.UUID `json:"follower_id"`
+	FolloweeID uuid.UUID `json:"followee_id"`
+	DeletedAt  int64     `json:"deleted_at_unix"`
+}
+
+type SubscriptionRequestedPayload struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FolloweeID  uuid.UUID `json:"followee_id"`
+	RequestedAt int64     `json:"requested_at_unix"`
+}
+
+type SubscriptionApprovedPayload struct {
+	FollowerID uuid.UUID `json:"follower_id"`
+	FolloweeID uuid.UUID `json:"followee_id"`
+	ApprovedAt int64     `json:"approved_at_unix"`
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Validator is implemented by payloads that check their fields once decoded
+type Validator interface {
+	Validate() error
+}
+
+// ids maps a payload's JSON field names to the IDs it can't do without
+type ids map[string]uuid.UUID
+
+func (m ids) require() error {
+	for name, id := range m {
+		if id == uuid.Nil {
+			return fmt.Errorf("missing %s", name)
+		}
+	}
+	return nil
+}
+
+func (p SubscriptionCreatedPayload) Validate() error {
+	return ids{"follower_id": p.FollowerID, "followee_id": p.FolloweeID}.require()
+}
+
+func (p SubscriptionDeletedPayload) Validate() error {
+	return ids{"follower_id": p.FollowerID, "followee_id": p.FolloweeID}.require()
+}
+
+func (p SubscriptionRequestedPayload) Validate() error {
+	return ids{"follower_id": p.FollowerID, "followee_id": p.FolloweeID}.require()
+}
+
+func (p SubscriptionApprovedPayload) Validate() error {
+	return ids{"follower_id": p.FollowerID, "followee_id": p.FolloweeID}.require()
+}
+
+func (e LikeEvent) Validate() error {
+	if e.TargetType == "" {
+		return errors.New("missing target_type")
+	}
+	return ids{"id": e.ID, "user_id": e.UserID, "target_id": e.TargetID}.require()
+}
+
+func (p UserBlockedPayload) Validate() error {
+	return ids{"blocker_id": p.BlockerID, "blocked_id": p.BlockedID}.require()
+}
+
+func (p UserUnblockedPayload) Validate() error {
+	return ids{"blocker_id": p.BlockerID, "blocked_id": p.BlockedID}.require()
+}
+
+func (p MuteChangedPayload) Validate() error {
+	return ids{"muter_id": p.MuterID, "muted_id": p.MutedID}.require()
+}
+
+func (p ListMemberAddedPayload) Validate() error {
+	return ids{"list_id": p.ListID, "owner_id": p.OwnerID, "member_id": p.MemberID}.require()
+}
+
+func (p ListMemberRemovedPayload) Validate() error {
+	return ids{"list_id": p.ListID, "owner_id": p.OwnerID, "member_id": p.MemberID}.require()
+}
+
+func (p ListDeletedPayload) Validate() error {
+	return ids{"list_id": p.ListID, "owner_id": p.OwnerID}.require()
+}
+
+func (p AbuseSuspectedPayload) Validate() error {
+	if p.Action == "" {
+		return errors.New("missing action")
+	}
+	return ids{"user_id": p.UserID}.require()
+}
+
+func (p UserCreatedPayload) Validate() error {
+	return ids{"user_id": p.UserID}.require()
+}
@@ -5,25 +5,69 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/Sayan80bayev/go-project/pkg/events"
 	"github.com/Sayan80bayev/go-project/pkg/logging"
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/sirupsen/logrus"
 )
 
+// Headers set on dead-lettered messages, next to the original ones
+const (
+	HeaderDLQTopic     = "dlq_original_topic"
+	HeaderDLQPartition = "dlq_original_partition"
+	HeaderDLQOffset    = "dlq_original_offset"
+	HeaderDLQError     = "dlq_error"
+	HeaderDLQAttempts  = "dlq_attempts"
+	HeaderDLQFailedAt  = "dlq_failed_at"
+)
+
+const dlqDeliveryTimeout = 10 * time.Second
+
 type ConsumerConfig struct {
 	BootstrapServers string
 	GroupID          string
 	Topics           []string
+
+	// MaxRetries is how many times a failing handler is retried before the message is dead-lettered
+	MaxRetries int
+	// RetryBaseDelay is the wait before the first retry, doubled for each next one up to RetryMaxDelay.
+	// Retries block the partition, so keep the total well under max.poll.interval.ms.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// DeadLetterTopic receives messages that can't be parsed or whose handler kept failing.
+	// Empty disables it: such messages are logged and skipped.
+	DeadLetterTopic string
 }
 
 // Generic event handler function type
 type EventHandler func(json.RawMessage) error
 
+// dispatchFunc handles a decoded envelope. Consumers keep one per event type:
+// RegisterHandler wraps an EventHandler, RegisterEvents binds an events.Registry.
+type dispatchFunc func(ctx context.Context, e events.Event) error
+
+func rawHandler(handler EventHandler) dispatchFunc {
+	return func(_ context.Context, e events.Event) error {
+		return handler(e.Data)
+	}
+}
+
+func bindRegistry(handlers map[string]dispatchFunc, r *events.Registry) {
+	for _, eventType := range r.Types() {
+		handlers[eventType] = r.Handle
+	}
+}
+
+// KafkaConsumer dispatches events to handlers by type. Offsets are committed by hand,
+// once a message was handled or handed off to the dead-letter topic, so nothing is skipped on a crash.
 type KafkaConsumer struct {
 	config   ConsumerConfig
 	consumer *kafka.Consumer
-	handlers map[string]EventHandler
+	dlq      *kafka.Producer
+	handlers map[string]dispatchFunc
 	log      *logrus.Logger
 }
 
@@ -35,6 +79,7 @@ func NewKafkaConsumer(cfg ConsumerConfig) (*KafkaConsumer, error) {
 		"bootstrap.servers":     cfg.BootstrapServers,
 		"group.id":              cfg.GroupID,
 		"auto.offset.reset":     "earliest",
+		"enable.auto.commit":    false,
 		"broker.address.family": "v4",
 	})
 	if err != nil {
@@ -44,16 +89,33 @@ func NewKafkaConsumer(cfg ConsumerConfig) (*KafkaConsumer, error) {
 	consumer := &KafkaConsumer{
 		config:   cfg,
 		consumer: c,
-		handlers: make(map[string]EventHandler),
+		handlers: make(map[string]dispatchFunc),
 		log:      logger,
 	}
 
+	if cfg.DeadLetterTopic != "" {
+		p, err := kafka.NewProducer(&kafka.ConfigMap{
+			"bootstrap.servers": cfg.BootstrapServers,
+			"acks":              "all",
+		})
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to create dead-letter producer: %w", err)
+		}
+		consumer.dlq = p
+	}
+
 	return consumer, nil
 }
 
 // RegisterHandler binds a handler to an event type
 func (c *KafkaConsumer) RegisterHandler(eventType string, handler EventHandler) {
-	c.handlers[eventType] = handler
+	c.handlers[eventType] = rawHandler(handler)
+}
+
+// RegisterEvents binds every event type r has a handler for
+func (c *KafkaConsumer) RegisterEvents(r *events.Registry) {
+	bindRegistry(c.handlers, r)
 }
 
 func (c *KafkaConsumer) Start(ctx context.Context) {
@@ -83,12 +145,23 @@ func (c *KafkaConsumer) Start(ctx context.Context) {
 			}
 
 			c.log.Infof("Received message: %s", string(msg.Value))
-			c.handleMessage(msg)
+			if !c.handleMessage(ctx, msg) {
+				// Not committed: rewind, so the message is read again instead of the next one
+				c.rewind(msg)
+				continue
+			}
+			if _, err := c.consumer.CommitMessage(msg); err != nil {
+				c.log.Warnf("Could not commit offset %v: %v", msg.TopicPartition, err)
+			}
 		}
 	}
 }
 
 func (c *KafkaConsumer) Close() {
+	if c.dlq != nil {
+		c.dlq.Flush(5000)
+		c.dlq.Close()
+	}
 	if err := c.consumer.Close(); err != nil {
 		c.log.Errorf("Could not close consumer connection gracefully: %v", err)
 	} else {
@@ -96,18 +169,119 @@ func (c *KafkaConsumer) Close() {
 	}
 }
 
-func (c *KafkaConsumer) handleMessage(msg *kafka.Message) {
-	var event events.Event
-	if err := json.Unmarshal(msg.Value, &event); err != nil {
+// handleMessage runs the handler with retries and dead-letters the message if it still fails.
+// Returns whether the offset may be committed.
+func (c *KafkaConsumer) handleMessage(ctx context.Context, msg *kafka.Message) bool {
+	event, err := events.Decode(msg.Value, headerValue(msg, "event_type"))
+	if err != nil {
 		c.log.Errorf("Error parsing message: %v", err)
-		return
+		return c.deadLetter(msg, fmt.Errorf("parse event: %w", err), 0)
 	}
 
-	if handler, ok := c.handlers[event.Type]; ok {
-		if err := handler(event.Data); err != nil {
-			c.log.Errorf("Handler for event %s failed: %v", event.Type, err)
-		}
-	} else {
+	handler, ok := c.handlers[event.Type]
+	if !ok {
 		c.log.Warnf("No handler registered for event type: %s", event.Type)
+		return true
+	}
+
+	attempts := 0
+	for {
+		attempts++
+		if err = handler(ctx, event); err == nil {
+			return true
+		}
+		if attempts > c.config.MaxRetries || events.Permanent(err) {
+			break
+		}
+
+		delay := c.retryDelay(attempts)
+		c.log.Warnf("Handler for event %s failed (attempt %d), retrying in %s: %v", event.Type, attempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+	}
+
+	c.log.Errorf("Handler for event %s failed after %d attempts: %v", event.Type, attempts, err)
+	return c.deadLetter(msg, err, attempts)
+}
+
+func headerValue(msg *kafka.Message, name string) string {
+	for _, h := range msg.Headers {
+		if h.Key == name {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// retryDelay returns the wait after the given failed attempt, doubling from RetryBaseDelay up to RetryMaxDelay.
+func (c *KafkaConsumer) retryDelay(attempt int) time.Duration {
+	d := c.config.RetryBaseDelay
+	for i := 1; i < attempt && (c.config.RetryMaxDelay <= 0 || d < c.config.RetryMaxDelay); i++ {
+		d *= 2
+	}
+	if c.config.RetryMaxDelay > 0 && d > c.config.RetryMaxDelay {
+		d = c.config.RetryMaxDelay
+	}
+	return d
+}
+
+// deadLetter copies the message to the dead-letter topic and waits for the broker to take it.
+// Without a dead-letter topic the message is dropped. Returns whether the offset may be committed.
+func (c *KafkaConsumer) deadLetter(msg *kafka.Message, cause error, attempts int) bool {
+	if c.dlq == nil {
+		c.log.Errorf("Dropping message at %v: %v", msg.TopicPartition, cause)
+		return true
+	}
+
+	headers := append([]kafka.Header{}, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: HeaderDLQTopic, Value: []byte(*msg.TopicPartition.Topic)},
+		kafka.Header{Key: HeaderDLQPartition, Value: []byte(strconv.Itoa(int(msg.TopicPartition.Partition)))},
+		kafka.Header{Key: HeaderDLQOffset, Value: []byte(msg.TopicPartition.Offset.String())},
+		kafka.Header{Key: HeaderDLQError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDLQFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+
+	if err := produceSync(c.dlq, &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &c.config.DeadLetterTopic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        headers,
+	}); err != nil {
+		c.log.Errorf("Could not dead-letter message at %v, will retry it: %v", msg.TopicPartition, err)
+		return false
+	}
+
+	c.log.Warnf("Message at %v moved to dead-letter topic %s", msg.TopicPartition, c.config.DeadLetterTopic)
+	return true
+}
+
+// rewind seeks the partition back to msg, so the next read returns it again.
+func (c *KafkaConsumer) rewind(msg *kafka.Message) {
+	if err := c.consumer.Seek(msg.TopicPartition, 0); err != nil {
+		c.log.Warnf("Could not rewind to %v: %v", msg.TopicPartition, err)
+	}
+}
+
+// produceSync produces msg and waits for its delivery report.
+func produceSync(p *kafka.Producer, msg *kafka.Message) error {
+	delivery := make(chan kafka.Event, 1)
+	if err := p.Produce(msg, delivery); err != nil {
+		return err
+	}
+
+	select {
+	case e := <-delivery:
+		m, ok := e.(*kafka.Message)
+		if !ok {
+			return fmt.Errorf("unexpected delivery event: %v", e)
+		}
+		return m.TopicPartition.Error
+	case <-time.After(dlqDeliveryTimeout):
+		return errors.New("delivery timed out")
 	}
 }
@@ -0,0 +1,120 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/sirupsen/logrus"
+)
+
+// DeadLetterReplayer moves messages from a dead-letter topic back to the topics they came from,
+// e.g. once the bug that made their handler fail is fixed.
+type DeadLetterReplayer struct {
+	topic    string
+	consumer *kafka.Consumer
+	producer *kafka.Producer
+	log      *logrus.Logger
+}
+
+// NewDeadLetterReplayer creates a replayer for the given dead-letter topic. Its progress is kept
+// under groupID, so each dead letter is replayed once however many times Replay is called.
+func NewDeadLetterReplayer(bootstrapServers, deadLetterTopic, groupID string) (*DeadLetterReplayer, error) {
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":     bootstrapServers,
+		"group.id":              groupID,
+		"auto.offset.reset":     "earliest",
+		"enable.auto.commit":    false,
+		"broker.address.family": "v4",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dead-letter consumer: %w", err)
+	}
+	if err := c.Subscribe(deadLetterTopic, nil); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", deadLetterTopic, err)
+	}
+
+	p, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": bootstrapServers,
+		"acks":              "all",
+	})
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to create replay producer: %w", err)
+	}
+
+	return &DeadLetterReplayer{
+		topic:    deadLetterTopic,
+		consumer: c,
+		producer: p,
+		log:      logging.GetLogger(),
+	}, nil
+}
+
+// Replay republishes up to limit dead letters to their original topics, with their original key and headers.
+// It returns how many were replayed once the limit is reached or no message arrived for idle.
+// A limit of 0 replays everything.
+func (r *DeadLetterReplayer) Replay(ctx context.Context, limit int, idle time.Duration) (int, error) {
+	replayed := 0
+	for limit <= 0 || replayed < limit {
+		if ctx.Err() != nil {
+			return replayed, ctx.Err()
+		}
+
+		msg, err := r.consumer.ReadMessage(idle)
+		if err != nil {
+			var kafkaErr kafka.Error
+			if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrTimedOut {
+				break
+			}
+			return replayed, fmt.Errorf("read dead letter: %w", err)
+		}
+
+		topic := ""
+		var headers []kafka.Header
+		for _, h := range msg.Headers {
+			switch {
+			case h.Key == HeaderDLQTopic:
+				topic = string(h.Value)
+			case strings.HasPrefix(h.Key, "dlq_"):
+				// failure details only make sense on the dead letter
+			default:
+				headers = append(headers, h)
+			}
+		}
+		if topic == "" {
+			r.log.Warnf("Dead letter at %v has no %s header, skipping", msg.TopicPartition, HeaderDLQTopic)
+		} else {
+			err := produceSync(r.producer, &kafka.Message{
+				TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+				Key:            msg.Key,
+				Value:          msg.Value,
+				Headers:        headers,
+			})
+			if err != nil {
+				return replayed, fmt.Errorf("replay to %s: %w", topic, err)
+			}
+			replayed++
+		}
+
+		if _, err := r.consumer.CommitMessage(msg); err != nil {
+			return replayed, fmt.Errorf("commit dead letter: %w", err)
+		}
+	}
+
+	r.log.Infof("Replayed %d messages from %s", replayed, r.topic)
+	return replayed, nil
+}
+
+func (r *DeadLetterReplayer) Close() {
+	r.producer.Flush(5000)
+	r.producer.Close()
+	if err := r.consumer.Close(); err != nil {
+		r.log.Errorf("Could not close dead-letter consumer gracefully: %v", err)
+	}
+}
@@ -13,11 +13,12 @@ import (
 type KafkaProducer struct {
 	producer *kafka.Producer
 	topic    string
+	source   string
 	log      *logrus.Logger
 }
 
-// NewKafkaProducer creates a new KafkaProducer instance
-func NewKafkaProducer(brokers, topic string) (*KafkaProducer, error) {
+// NewKafkaProducer creates a new KafkaProducer instance. source names the service in the envelope of its events.
+func NewKafkaProducer(brokers, topic, source string) (*KafkaProducer, error) {
 	logger := logging.GetLogger()
 
 	p, err := kafka.NewProducer(&kafka.ConfigMap{
@@ -33,11 +34,14 @@ func NewKafkaProducer(brokers, topic string) (*KafkaProducer, error) {
 	return &KafkaProducer{
 		producer: p,
 		topic:    topic,
+		source:   source,
 		log:      logger,
 	}, nil
 }
 
-func (p *KafkaProducer) Produce(ctx context.Context, eventType string, data interface{}) error {
+// Produce publishes the event to the producer's topic. Keyed messages are hashed to a partition,
+// so events with the same key are consumed in order; unkeyed ones go to any partition.
+func (p *KafkaProducer) Produce(ctx context.Context, eventType string, data interface{}, opts ...ProduceOption) error {
 	select {
 	case <-ctx.Done():
 		p.log.Warnf("Produce cancelled by context: %v", ctx.Err())
@@ -45,12 +49,11 @@ func (p *KafkaProducer) Produce(ctx context.Context, eventType string, data inte
 	default:
 	}
 
-	event := struct {
-		Type string      `json:"type"`
-		Data interface{} `json:"data"`
-	}{
-		Type: eventType,
-		Data: data,
+	o := NewProduceOptions(opts...)
+	event, err := NewEvent(ctx, eventType, p.source, data, o)
+	if err != nil {
+		p.log.Warnf("Failed to build event: %v", err)
+		return fmt.Errorf("marshal event failed: %w", err)
 	}
 
 	jsonData, err := json.Marshal(event)
@@ -59,16 +62,28 @@ func (p *KafkaProducer) Produce(ctx context.Context, eventType string, data inte
 		return fmt.Errorf("marshal event failed: %w", err)
 	}
 
-	err = p.producer.Produce(&kafka.Message{
+	msg := &kafka.Message{
 		TopicPartition: kafka.TopicPartition{Topic: &p.topic, Partition: kafka.PartitionAny},
 		Value:          jsonData,
-	}, nil)
+		Headers: []kafka.Header{
+			{Key: "event_type", Value: []byte(eventType)},
+			{Key: "event_id", Value: []byte(event.ID)},
+		},
+	}
+	if o.Key != "" {
+		msg.Key = []byte(o.Key)
+	}
+	for name, value := range o.Headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: name, Value: []byte(value)})
+	}
+
+	err = p.producer.Produce(msg, nil)
 	if err != nil {
 		p.log.Warnf("Failed to produce message: %v", err)
 		return fmt.Errorf("produce message failed: %w", err)
 	}
 
-	p.log.Infof("Message produced to topic %s: %s", p.topic, string(jsonData))
+	p.log.Infof("Message produced to topic %s (key=%s): %s", p.topic, o.Key, string(jsonData))
 	return nil
 }
 
@@ -0,0 +1,418 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// MemoryMessage is a message as the in-memory broker stored it
+type MemoryMessage struct {
+	Topic   string
+	Key     string
+	Headers map[string]string
+	Event   events.Event
+}
+
+// MemoryBroker is an in-process stand-in for Kafka, for tests and for running a service without infrastructure.
+// Every consumer group subscribed to a topic gets each message; within a group, messages with the same key
+// go to the same member, like partitions. A broker made with RecordPublished also keeps every published
+// message for the Expect* assertions; otherwise nothing outlives its delivery.
+type MemoryBroker struct {
+	mu        sync.Mutex
+	groups    map[string]*memoryGroup // by topic + group
+	record    bool
+	published []MemoryMessage
+	pending   int           // messages handed to consumers and not yet handled or dropped
+	idle      chan struct{} // closed whenever pending is zero
+	log       *logrus.Logger
+}
+
+type memoryGroup struct {
+	topic   string
+	members []*MemoryConsumer
+	next    int // round-robin position for unkeyed messages
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	idle := make(chan struct{})
+	close(idle)
+	return &MemoryBroker{groups: make(map[string]*memoryGroup), idle: idle, log: logging.GetLogger()}
+}
+
+// RecordPublished makes the broker keep published messages for Published and the Expect* assertions.
+// Meant for tests: the history grows with every message until Reset.
+func (b *MemoryBroker) RecordPublished() *MemoryBroker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.record = true
+	return b
+}
+
+// Producer returns a Producer publishing to topic on behalf of the source service
+func (b *MemoryBroker) Producer(topic, source string) *MemoryProducer {
+	return &MemoryProducer{broker: b, topic: topic, source: source}
+}
+
+// Consumer returns a Consumer that joins group on each of topics. Messages published before it was created
+// are not delivered to it. Until it is started, messages queue up without a bound, so producers never wait,
+// not even handlers publishing to their own group; once its Start returns it leaves the group and gets nothing more.
+func (b *MemoryBroker) Consumer(group string, topics ...string) *MemoryConsumer {
+	c := &MemoryConsumer{
+		broker:   b,
+		group:    group,
+		ready:    make(chan struct{}, 1),
+		handlers: make(map[string]dispatchFunc),
+		log:      b.log,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, topic := range topics {
+		g, ok := b.groups[topic+"\x00"+group]
+		if !ok {
+			g = &memoryGroup{topic: topic}
+			b.groups[topic+"\x00"+group] = g
+		}
+		g.members = append(g.members, c)
+	}
+	return c
+}
+
+func (b *MemoryBroker) publish(m MemoryMessage) {
+	b.mu.Lock()
+	if b.record {
+		b.published = append(b.published, m)
+	}
+	var targets []*MemoryConsumer
+	for _, g := range b.groups {
+		if g.topic != m.Topic || len(g.members) == 0 {
+			continue
+		}
+		var member *MemoryConsumer
+		if m.Key != "" {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(m.Key))
+			member = g.members[h.Sum32()%uint32(len(g.members))]
+		} else {
+			member = g.members[g.next%len(g.members)]
+			g.next++
+		}
+		targets = append(targets, member)
+	}
+	if b.pending == 0 && len(targets) > 0 {
+		b.idle = make(chan struct{})
+	}
+	b.pending += len(targets)
+	b.mu.Unlock()
+
+	for _, c := range targets {
+		c.deliver(m)
+	}
+}
+
+// settle marks n delivered messages as handled or dropped
+func (b *MemoryBroker) settle(n int) {
+	if n == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending -= n
+	if b.pending == 0 {
+		close(b.idle)
+	}
+}
+
+// leave takes a stopped consumer out of its groups, so their other members get its share
+func (b *MemoryBroker) leave(c *MemoryConsumer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, g := range b.groups {
+		for i, member := range g.members {
+			if member == c {
+				g.members = append(g.members[:i], g.members[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Wait blocks until every message delivered so far was handled, or ctx is done.
+// Consumers must be started, or it waits for ctx.
+func (b *MemoryBroker) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	idle := b.idle
+	b.mu.Unlock()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Published returns the messages published so far, optionally only those of the given event types.
+// It is always empty unless the broker was made with RecordPublished.
+func (b *MemoryBroker) Published(eventTypes ...string) []MemoryMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var out []MemoryMessage
+	for _, m := range b.published {
+		if len(eventTypes) == 0 || contains(eventTypes, m.Event.Type) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Reset forgets the recorded messages. Consumers stay subscribed.
+func (b *MemoryBroker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.published = nil
+}
+
+// TestingT is the part of testing.TB the assertions use
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// ExpectEvent checks that an event of eventType was published whose data equals payload once both are
+// encoded as JSON, so a struct matches the map or struct a producer sent. A nil payload matches any data.
+func (b *MemoryBroker) ExpectEvent(t TestingT, eventType string, payload interface{}) bool {
+	t.Helper()
+	if !b.recording(t) {
+		return false
+	}
+
+	msgs := b.Published(eventType)
+	if len(msgs) == 0 {
+		t.Errorf("expected event %s, none was published", eventType)
+		return false
+	}
+	if payload == nil {
+		return true
+	}
+
+	want, err := normalizeJSON(payload)
+	if err != nil {
+		t.Errorf("expected payload for %s can't be encoded: %v", eventType, err)
+		return false
+	}
+	for _, m := range msgs {
+		got, err := normalizeJSON(m.Event.Data)
+		if err == nil && reflect.DeepEqual(got, want) {
+			return true
+		}
+	}
+
+	t.Errorf("expected event %s with payload %v, got %d with other payloads: %s", eventType, want, len(msgs), dataOf(msgs))
+	return false
+}
+
+// ExpectNoEvent checks that no event of eventType was published
+func (b *MemoryBroker) ExpectNoEvent(t TestingT, eventType string) bool {
+	t.Helper()
+	if !b.recording(t) {
+		return false
+	}
+	if msgs := b.Published(eventType); len(msgs) > 0 {
+		t.Errorf("expected no event %s, got %d: %s", eventType, len(msgs), dataOf(msgs))
+		return false
+	}
+	return true
+}
+
+// ExpectEventCount checks how many events of eventType were published
+func (b *MemoryBroker) ExpectEventCount(t TestingT, eventType string, n int) bool {
+	t.Helper()
+	if !b.recording(t) {
+		return false
+	}
+	if got := len(b.Published(eventType)); got != n {
+		t.Errorf("expected %d events %s, got %d", n, eventType, got)
+		return false
+	}
+	return true
+}
+
+func (b *MemoryBroker) recording(t TestingT) bool {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.record {
+		t.Errorf("broker doesn't record published messages, create it with RecordPublished")
+	}
+	return b.record
+}
+
+// MemoryProducer publishes to a MemoryBroker topic, in the same envelope as the other producers
+type MemoryProducer struct {
+	broker *MemoryBroker
+	topic  string
+	source string
+}
+
+func (p *MemoryProducer) Produce(ctx context.Context, eventType string, data interface{}, opts ...ProduceOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	o := NewProduceOptions(opts...)
+	event, err := NewEvent(ctx, eventType, p.source, data, o)
+	if err != nil {
+		return fmt.Errorf("marshal event failed: %w", err)
+	}
+
+	p.broker.publish(MemoryMessage{
+		Topic:   p.topic,
+		Key:     o.Key,
+		Headers: o.Headers,
+		Event:   event,
+	})
+	return nil
+}
+
+func (p *MemoryProducer) Close() {}
+
+// MemoryConsumer is one member of a consumer group on a MemoryBroker. Like KafkaConsumer it dispatches
+// by event type; failed handlers are logged, as there is nothing to retry against.
+type MemoryConsumer struct {
+	broker   *MemoryBroker
+	group    string
+	handlers map[string]dispatchFunc
+	log      *logrus.Logger
+
+	mu     sync.Mutex
+	queue  []MemoryMessage
+	closed bool          // set when Start returns; later messages are dropped
+	ready  chan struct{} // signalled when queue gets a message
+}
+
+// RegisterHandler binds a handler to an event type
+func (c *MemoryConsumer) RegisterHandler(eventType string, handler EventHandler) {
+	c.handlers[eventType] = rawHandler(handler)
+}
+
+// RegisterEvents binds every event type r has a handler for
+func (c *MemoryConsumer) RegisterEvents(r *events.Registry) {
+	bindRegistry(c.handlers, r)
+}
+
+func (c *MemoryConsumer) Start(ctx context.Context) {
+	defer c.stop()
+	c.log.Infof("MemoryConsumer started (group=%s)", c.group)
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info("MemoryConsumer stopped by context cancellation")
+			return
+		case <-c.ready:
+			for ctx.Err() == nil {
+				m, ok := c.next()
+				if !ok {
+					break
+				}
+				c.handle(ctx, m)
+				c.broker.settle(1)
+			}
+		}
+	}
+}
+
+// deliver queues m without blocking, or drops it if the consumer has stopped
+func (c *MemoryConsumer) deliver(m MemoryMessage) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		c.broker.settle(1)
+		return
+	}
+	c.queue = append(c.queue, m)
+	c.mu.Unlock()
+
+	select {
+	case c.ready <- struct{}{}:
+	default: // already signalled
+	}
+}
+
+func (c *MemoryConsumer) next() (MemoryMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.queue) == 0 {
+		return MemoryMessage{}, false
+	}
+	m := c.queue[0]
+	c.queue[0] = MemoryMessage{}
+	c.queue = c.queue[1:]
+	return m, true
+}
+
+// stop leaves the consumer's groups and drops whatever is still queued, so Wait doesn't hang on it
+func (c *MemoryConsumer) stop() {
+	c.broker.leave(c)
+
+	c.mu.Lock()
+	c.closed = true
+	dropped := len(c.queue)
+	c.queue = nil
+	c.mu.Unlock()
+
+	c.broker.settle(dropped)
+}
+
+func (c *MemoryConsumer) handle(ctx context.Context, m MemoryMessage) {
+	handler, ok := c.handlers[m.Event.Type]
+	if !ok {
+		return
+	}
+	if err := handler(ctx, m.Event); err != nil {
+		c.log.Errorf("Handler for event %s failed: %v", m.Event.Type, err)
+	}
+}
+
+func (c *MemoryConsumer) Close() {}
+
+func normalizeJSON(v interface{}) (interface{}, error) {
+	raw, ok := v.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	var out interface{}
+	err := json.Unmarshal(raw, &out)
+	return out, err
+}
+
+func dataOf(msgs []MemoryMessage) string {
+	var s string
+	for i, m := range msgs {
+		if i > 0 {
+			s += ", "
+		}
+		s += string(m.Event.Data)
+	}
+	return s
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
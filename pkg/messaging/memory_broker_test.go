@@ -0,0 +1,345 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testTopic = "test-events"
+
+type testPayload struct {
+	Key string `json:"key"`
+	N   int    `json:"n"`
+}
+
+// received collects what a consumer's handler was given
+type received struct {
+	mu       sync.Mutex
+	payloads []testPayload
+}
+
+func (r *received) handler(data json.RawMessage) error {
+	var p testPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.payloads = append(r.payloads, p)
+	return nil
+}
+
+func (r *received) keys() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make(map[string]int)
+	for _, p := range r.payloads {
+		keys[p.Key]++
+	}
+	return keys
+}
+
+func (r *received) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.payloads)
+}
+
+// start runs c until the returned stop is called or the test ends
+func start(t *testing.T, c *MemoryConsumer) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Start(ctx)
+		close(done)
+	}()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+func subscribe(t *testing.T, b *MemoryBroker, group string) *received {
+	t.Helper()
+	r := &received{}
+	c := b.Consumer(group, testTopic)
+	c.RegisterHandler("test.happened", r.handler)
+	start(t, c)
+	return r
+}
+
+func produce(t *testing.T, p *MemoryProducer, key string, n int) {
+	t.Helper()
+	var opts []ProduceOption
+	if key != "" {
+		opts = append(opts, WithKey(key))
+	}
+	if err := p.Produce(context.Background(), "test.happened", testPayload{Key: key, N: n}, opts...); err != nil {
+		t.Fatalf("Produce: %v", err)
+	}
+}
+
+func wait(t *testing.T, b *MemoryBroker) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := b.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+}
+
+func TestMemoryBrokerFansOutToEveryGroup(t *testing.T) {
+	b := NewMemoryBroker()
+	first := subscribe(t, b, "first")
+	second := subscribe(t, b, "second")
+	p := b.Producer(testTopic, "test")
+
+	for i := 0; i < 5; i++ {
+		produce(t, p, "", i)
+	}
+	wait(t, b)
+
+	if got := first.count(); got != 5 {
+		t.Errorf("first group got %d messages, want 5", got)
+	}
+	if got := second.count(); got != 5 {
+		t.Errorf("second group got %d messages, want 5", got)
+	}
+}
+
+func TestMemoryBrokerSplitsUnkeyedMessagesWithinGroup(t *testing.T) {
+	b := NewMemoryBroker()
+	a := subscribe(t, b, "group")
+	c := subscribe(t, b, "group")
+	p := b.Producer(testTopic, "test")
+
+	for i := 0; i < 10; i++ {
+		produce(t, p, "", i)
+	}
+	wait(t, b)
+
+	if a.count() != 5 || c.count() != 5 {
+		t.Errorf("members got %d and %d messages, want 5 each", a.count(), c.count())
+	}
+}
+
+func TestMemoryBrokerKeepsKeysOnOneMember(t *testing.T) {
+	b := NewMemoryBroker()
+	members := []*received{subscribe(t, b, "group"), subscribe(t, b, "group"), subscribe(t, b, "group")}
+	p := b.Producer(testTopic, "test")
+
+	for i := 0; i < 40; i++ {
+		produce(t, p, fmt.Sprintf("key-%d", i%8), i)
+	}
+	wait(t, b)
+
+	owner := make(map[string]int)
+	total := 0
+	for i, m := range members {
+		for key, n := range m.keys() {
+			if prev, ok := owner[key]; ok {
+				t.Errorf("%s went to members %d and %d", key, prev, i)
+			}
+			owner[key] = i
+			total += n
+		}
+	}
+	if total != 40 {
+		t.Errorf("group got %d messages, want 40", total)
+	}
+
+	// Order within a key is kept too
+	for _, m := range members {
+		last := make(map[string]int)
+		m.mu.Lock()
+		for _, pl := range m.payloads {
+			if n, ok := last[pl.Key]; ok && pl.N < n {
+				t.Errorf("%s: message %d handled after %d", pl.Key, pl.N, n)
+			}
+			last[pl.Key] = pl.N
+		}
+		m.mu.Unlock()
+	}
+}
+
+func TestMemoryBrokerStoppedConsumerLeavesGroup(t *testing.T) {
+	b := NewMemoryBroker()
+	stopped := &received{}
+	c := b.Consumer("group", testTopic)
+	c.RegisterHandler("test.happened", stopped.handler)
+	stop := start(t, c)
+	remaining := subscribe(t, b, "group")
+	p := b.Producer(testTopic, "test")
+
+	stop()
+	for i := 0; i < 10; i++ {
+		produce(t, p, fmt.Sprintf("key-%d", i), i)
+	}
+	wait(t, b)
+
+	if got := stopped.count(); got != 0 {
+		t.Errorf("stopped consumer got %d messages, want 0", got)
+	}
+	if got := remaining.count(); got != 10 {
+		t.Errorf("remaining consumer got %d messages, want 10", got)
+	}
+}
+
+func TestMemoryBrokerDropsQueueOfStoppedConsumer(t *testing.T) {
+	b := NewMemoryBroker()
+	c := b.Consumer("group", testTopic)
+	p := b.Producer(testTopic, "test")
+
+	// Queued before the consumer ever runs
+	for i := 0; i < 3; i++ {
+		produce(t, p, "", i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Start(ctx)
+
+	wait(t, b)
+	produce(t, p, "", 3) // nobody is left in the group
+	wait(t, b)
+}
+
+func TestMemoryBrokerWaitReturnsOnContext(t *testing.T) {
+	b := NewMemoryBroker()
+	_ = b.Consumer("group", testTopic) // never started
+	produce(t, b.Producer(testTopic, "test"), "", 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestMemoryBrokerWaitWithNothingInFlight(t *testing.T) {
+	b := NewMemoryBroker()
+	wait(t, b)
+
+	subscribe(t, b, "group")
+	produce(t, b.Producer(testTopic, "test"), "", 1)
+	wait(t, b)
+	wait(t, b)
+}
+
+func TestMemoryBrokerHandlerCanPublishToOwnGroup(t *testing.T) {
+	const fanOut = 5000
+
+	b := NewMemoryBroker()
+	p := b.Producer(testTopic, "test")
+	got := &received{}
+	c := b.Consumer("group", testTopic)
+	c.RegisterHandler("test.happened", func(data json.RawMessage) error {
+		var pl testPayload
+		if err := json.Unmarshal(data, &pl); err != nil {
+			return err
+		}
+		if pl.Key == "root" {
+			// More than any fixed buffer would hold, from the goroutine that drains it
+			for i := 0; i < fanOut; i++ {
+				if err := p.Produce(context.Background(), "test.happened", testPayload{Key: "child", N: i}); err != nil {
+					return err
+				}
+			}
+		}
+		return got.handler(data)
+	})
+	start(t, c)
+
+	produce(t, p, "root", 0)
+	wait(t, b)
+
+	if n := got.count(); n != fanOut+1 {
+		t.Errorf("got %d messages, want %d", n, fanOut+1)
+	}
+}
+
+// fakeT records the failures the Expect* assertions report
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMemoryBrokerExpectEvent(t *testing.T) {
+	b := NewMemoryBroker().RecordPublished()
+	p := b.Producer(testTopic, "test")
+	produce(t, p, "a", 1)
+	produce(t, p, "b", 2)
+
+	ft := &fakeT{}
+	if !b.ExpectEvent(ft, "test.happened", testPayload{Key: "b", N: 2}) {
+		t.Errorf("ExpectEvent with a published payload failed: %v", ft.errors)
+	}
+	if !b.ExpectEvent(ft, "test.happened", map[string]interface{}{"key": "a", "n": 1}) {
+		t.Errorf("ExpectEvent with the payload as a map failed: %v", ft.errors)
+	}
+	if !b.ExpectEvent(ft, "test.happened", nil) {
+		t.Errorf("ExpectEvent with any payload failed: %v", ft.errors)
+	}
+	if !b.ExpectEventCount(ft, "test.happened", 2) {
+		t.Errorf("ExpectEventCount failed: %v", ft.errors)
+	}
+	if !b.ExpectNoEvent(ft, "test.other") {
+		t.Errorf("ExpectNoEvent failed: %v", ft.errors)
+	}
+	if len(ft.errors) > 0 {
+		t.Fatalf("passing assertions reported %v", ft.errors)
+	}
+
+	for name, ok := range map[string]bool{
+		"wrong payload": b.ExpectEvent(ft, "test.happened", testPayload{Key: "c", N: 3}),
+		"missing event": b.ExpectEvent(ft, "test.other", nil),
+		"wrong count":   b.ExpectEventCount(ft, "test.happened", 1),
+		"unwanted":      b.ExpectNoEvent(ft, "test.happened"),
+	} {
+		if ok {
+			t.Errorf("%s: assertion passed", name)
+		}
+	}
+	if len(ft.errors) != 4 {
+		t.Errorf("failing assertions reported %d errors, want 4: %v", len(ft.errors), ft.errors)
+	}
+
+	b.Reset()
+	ft = &fakeT{}
+	if !b.ExpectNoEvent(ft, "test.happened") {
+		t.Errorf("events survived Reset: %v", ft.errors)
+	}
+}
+
+func TestMemoryBrokerExpectEventNeedsRecording(t *testing.T) {
+	b := NewMemoryBroker()
+	produce(t, b.Producer(testTopic, "test"), "a", 1)
+
+	ft := &fakeT{}
+	if b.ExpectEvent(ft, "test.happened", nil) {
+		t.Error("ExpectEvent passed on a broker that doesn't record")
+	}
+	if len(ft.errors) != 1 {
+		t.Errorf("got %d errors, want 1: %v", len(ft.errors), ft.errors)
+	}
+	if got := b.Published(); len(got) != 0 {
+		t.Errorf("broker kept %d messages without recording", len(got))
+	}
+}
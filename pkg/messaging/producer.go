@@ -1,8 +1,122 @@
 package messaging
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/Sayan80bayev/go-project/pkg/events"
+)
 
 type Producer interface {
-    Produce(ctx context.Context, eventType string, data interface{}) error
-    Close()
-}
\ No newline at end of file
+	// Produce publishes data as an event of the given type, wrapped in an events.Event envelope.
+	// Options set a partition key, headers and envelope fields.
+	Produce(ctx context.Context, eventType string, data interface{}, opts ...ProduceOption) error
+	Close()
+}
+
+// ProduceOptions are the per-message settings collected from ProduceOption values
+type ProduceOptions struct {
+	// Key keeps messages with the same key in order: Kafka sends them to one partition,
+	// RabbitMQ passes it on in the partition_key header
+	Key     string
+	Headers map[string]string
+
+	// Envelope fields; zero values are filled in by NewEvent
+	EventID       string
+	OccurredAt    time.Time
+	CorrelationID string
+	Subject       string // defaults to Key
+	Version       int
+}
+
+type ProduceOption func(*ProduceOptions)
+
+// WithKey sets the partition key of the message. An empty key leaves partitioning to the transport.
+func WithKey(key string) ProduceOption {
+	return func(o *ProduceOptions) {
+		o.Key = key
+	}
+}
+
+// WithHeader adds a header to the message
+func WithHeader(name, value string) ProduceOption {
+	return func(o *ProduceOptions) {
+		if o.Headers == nil {
+			o.Headers = make(map[string]string)
+		}
+		o.Headers[name] = value
+	}
+}
+
+// WithEventID sets the event ID, e.g. to one stored with the event so redeliveries can be recognized
+func WithEventID(id string) ProduceOption {
+	return func(o *ProduceOptions) {
+		o.EventID = id
+	}
+}
+
+// WithOccurredAt sets when the change happened, if it wasn't just now
+func WithOccurredAt(t time.Time) ProduceOption {
+	return func(o *ProduceOptions) {
+		o.OccurredAt = t
+	}
+}
+
+// WithCorrelationID overrides the correlation ID taken from the context
+func WithCorrelationID(id string) ProduceOption {
+	return func(o *ProduceOptions) {
+		o.CorrelationID = id
+	}
+}
+
+// WithSubject sets the entity the event is about when it differs from the partition key
+func WithSubject(subject string) ProduceOption {
+	return func(o *ProduceOptions) {
+		o.Subject = subject
+	}
+}
+
+// WithVersion sets the schema version of the payload
+func WithVersion(version int) ProduceOption {
+	return func(o *ProduceOptions) {
+		o.Version = version
+	}
+}
+
+// NewEvent builds the envelope a producer sends for data, applying the envelope fields of o
+func NewEvent(ctx context.Context, eventType, source string, data interface{}, o ProduceOptions) (events.Event, error) {
+	if o.CorrelationID != "" {
+		ctx = events.WithCorrelationID(ctx, o.CorrelationID)
+	}
+	e, err := events.New(ctx, eventType, source, data)
+	if err != nil {
+		return events.Event{}, err
+	}
+
+	if o.EventID != "" {
+		if e.CorrelationID == e.ID {
+			e.CorrelationID = o.EventID
+		}
+		e.ID = o.EventID
+	}
+	if !o.OccurredAt.IsZero() {
+		e.OccurredAt = o.OccurredAt.UTC()
+	}
+	if o.Version > 0 {
+		e.Version = o.Version
+	}
+	e.Subject = o.Subject
+	if e.Subject == "" {
+		e.Subject = o.Key
+	}
+	return e, nil
+}
+
+// NewProduceOptions applies opts in order
+func NewProduceOptions(opts ...ProduceOption) ProduceOptions {
+	var o ProduceOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
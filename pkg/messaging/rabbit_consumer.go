@@ -0,0 +1,297 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// headerRetryCount counts how many times a message was sent back for another attempt
+	headerRetryCount = "x-retry-count"
+	// headerRoutingKey keeps the event type of a retried message, whose routing key is the queue name
+	headerRoutingKey = "x-original-routing-key"
+)
+
+type RabbitConsumerConfig struct {
+	URL      string
+	Exchange string // topic exchange the events are published to
+	Queue    string
+	// RoutingKeys the queue is bound with. Empty binds the event types of the registered handlers.
+	RoutingKeys []string
+	// Prefetch is how many unacknowledged messages the broker hands out at once
+	Prefetch int
+
+	// MaxRetries is how many times a failing message is retried before it is dead-lettered
+	MaxRetries int
+	// RetryDelay holds failed messages in a retry queue before they come back. Zero requeues at once.
+	RetryDelay time.Duration
+	// DeadLetterExchange receives messages that failed for good, routed by event type.
+	// Defaults to Queue + ".dlx"; a Queue + ".dlq" queue bound to everything is declared on it.
+	DeadLetterExchange string
+}
+
+// RabbitConsumer dispatches events from a RabbitMQ queue to handlers by routing key.
+// Messages are acked once handled; failures are retried up to MaxRetries and then dead-lettered.
+type RabbitConsumer struct {
+	config   RabbitConsumerConfig
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	handlers map[string]dispatchFunc
+	log      *logrus.Logger
+}
+
+// NewRabbitConsumer connects to RabbitMQ and declares the queue with its retry and dead-letter topology.
+func NewRabbitConsumer(cfg RabbitConsumerConfig) (*RabbitConsumer, error) {
+	if cfg.DeadLetterExchange == "" {
+		cfg.DeadLetterExchange = cfg.Queue + ".dlx"
+	}
+	if cfg.Prefetch <= 0 {
+		cfg.Prefetch = 10
+	}
+
+	conn, err := amqp.Dial(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	// Retries are republished on this channel; confirms tell when the copy is safe before the original is acked
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	c := &RabbitConsumer{
+		config:   cfg,
+		conn:     conn,
+		channel:  ch,
+		handlers: make(map[string]dispatchFunc),
+		log:      logging.GetLogger(),
+	}
+	if err := c.declare(); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// declare sets up the exchanges and queues. Declarations are idempotent, so every replica runs them.
+func (c *RabbitConsumer) declare() error {
+	cfg := c.config
+	if err := c.channel.ExchangeDeclare(cfg.Exchange, "topic", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+	if err := c.channel.ExchangeDeclare(cfg.DeadLetterExchange, "topic", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+
+	dlq := cfg.Queue + ".dlq"
+	if _, err := c.channel.QueueDeclare(dlq, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+	if err := c.channel.QueueBind(dlq, "#", cfg.DeadLetterExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	if _, err := c.channel.QueueDeclare(cfg.Queue, true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange": cfg.DeadLetterExchange,
+	}); err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if cfg.RetryDelay > 0 {
+		// Expired messages go back to the main queue through the default exchange
+		if _, err := c.channel.QueueDeclare(c.retryQueue(), true, false, false, false, amqp.Table{
+			"x-message-ttl":             cfg.RetryDelay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": cfg.Queue,
+		}); err != nil {
+			return fmt.Errorf("failed to declare retry queue: %w", err)
+		}
+	}
+
+	if err := c.channel.Qos(cfg.Prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+	return nil
+}
+
+func (c *RabbitConsumer) retryQueue() string {
+	return c.config.Queue + ".retry"
+}
+
+// RegisterHandler binds a handler to an event type, which is also the routing key it is published with
+func (c *RabbitConsumer) RegisterHandler(eventType string, handler EventHandler) {
+	c.handlers[eventType] = rawHandler(handler)
+}
+
+// RegisterEvents binds every event type r has a handler for
+func (c *RabbitConsumer) RegisterEvents(r *events.Registry) {
+	bindRegistry(c.handlers, r)
+}
+
+func (c *RabbitConsumer) Start(ctx context.Context) {
+	keys := c.config.RoutingKeys
+	if len(keys) == 0 {
+		for eventType := range c.handlers {
+			keys = append(keys, eventType)
+		}
+	}
+	for _, key := range keys {
+		if err := c.channel.QueueBind(c.config.Queue, key, c.config.Exchange, false, nil); err != nil {
+			c.log.Errorf("Error binding queue %s to %s: %v", c.config.Queue, key, err)
+			return
+		}
+	}
+
+	deliveries, err := c.channel.ConsumeWithContext(ctx, c.config.Queue, "", false, false, false, false, nil)
+	if err != nil {
+		c.log.Errorf("Error consuming from queue %s: %v", c.config.Queue, err)
+		return
+	}
+
+	c.log.Infof("RabbitConsumer started on queue %s (keys=%v)", c.config.Queue, keys)
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info("RabbitConsumer stopped by context cancellation")
+			return
+		case d, ok := <-deliveries:
+			if !ok {
+				c.log.Warn("RabbitConsumer delivery channel closed")
+				return
+			}
+			c.handleDelivery(ctx, d)
+		}
+	}
+}
+
+func (c *RabbitConsumer) Close() {
+	if c.channel != nil {
+		_ = c.channel.Close()
+	}
+	if err := c.conn.Close(); err != nil {
+		c.log.Errorf("Could not close RabbitMQ connection gracefully: %v", err)
+	} else {
+		c.log.Info("RabbitMQ consumer closed gracefully")
+	}
+}
+
+func (c *RabbitConsumer) handleDelivery(ctx context.Context, d amqp.Delivery) {
+	eventType := d.RoutingKey
+	if key, ok := d.Headers[headerRoutingKey].(string); ok {
+		eventType = key
+	}
+	event, err := events.Decode(d.Body, eventType)
+	if err != nil {
+		c.log.Errorf("Error parsing message, dead-lettering: %v", err)
+		if nerr := d.Nack(false, false); nerr != nil {
+			c.log.Errorf("Could not nack message: %v", nerr)
+		}
+		return
+	}
+	eventType = event.Type
+
+	handler, ok := c.handlers[eventType]
+	if !ok {
+		c.log.Warnf("No handler registered for event type: %s", eventType)
+		c.ack(d)
+		return
+	}
+
+	err = handler(ctx, event)
+	if err == nil {
+		c.ack(d)
+		return
+	}
+
+	retries := retryCount(d.Headers)
+	if retries >= c.config.MaxRetries || events.Permanent(err) {
+		c.log.Errorf("Handler for event %s failed after %d retries, dead-lettering: %v", eventType, retries, err)
+		if nerr := d.Nack(false, false); nerr != nil {
+			c.log.Errorf("Could not nack message: %v", nerr)
+		}
+		return
+	}
+
+	c.log.Warnf("Handler for event %s failed (retry %d of %d): %v", eventType, retries+1, c.config.MaxRetries, err)
+	c.retry(ctx, d, retries+1)
+}
+
+// retry publishes a copy of the message with a bumped retry count, to the retry queue if there is one,
+// and acks the original once the broker has confirmed the copy. If the copy can't be published or isn't
+// confirmed the original is requeued as is.
+func (c *RabbitConsumer) retry(ctx context.Context, d amqp.Delivery, retries int) {
+	queue := c.config.Queue
+	if c.config.RetryDelay > 0 {
+		queue = c.retryQueue()
+	}
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[headerRetryCount] = int32(retries)
+	if _, ok := headers[headerRoutingKey]; !ok {
+		headers[headerRoutingKey] = d.RoutingKey
+	}
+
+	confirm, err := c.channel.PublishWithDeferredConfirmWithContext(ctx, "", queue, false, false, amqp.Publishing{
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Headers:      headers,
+		Body:         d.Body,
+	})
+	if err != nil {
+		c.log.Errorf("Could not schedule retry, requeueing: %v", err)
+		c.requeue(d)
+		return
+	}
+
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		c.log.Errorf("Retry was not confirmed, requeueing: %v", err)
+		c.requeue(d)
+		return
+	}
+	if !acked {
+		c.log.Error("Broker refused the retry, requeueing")
+		c.requeue(d)
+		return
+	}
+	c.ack(d)
+}
+
+func (c *RabbitConsumer) ack(d amqp.Delivery) {
+	if err := d.Ack(false); err != nil {
+		c.log.Errorf("Could not ack message: %v", err)
+	}
+}
+
+func (c *RabbitConsumer) requeue(d amqp.Delivery) {
+	if err := d.Nack(false, true); err != nil {
+		c.log.Errorf("Could not nack message: %v", err)
+	}
+}
+
+func retryCount(headers amqp.Table) int {
+	switch v := headers[headerRetryCount].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	HeaderCorrelationID = "X-Correlation-ID"
+	HeaderRequestID     = "X-Request-ID"
+	headerTraceParent   = "traceparent"
+
+	maxCorrelationIDLen = 128
+)
+
+// Correlation кладёт в контекст запроса ID, которым помечаются все события, порождённые запросом.
+// ID берётся из X-Correlation-ID, X-Request-ID или trace-id заголовка traceparent, иначе генерируется,
+// и возвращается клиенту в X-Correlation-ID.
+func Correlation(c *gin.Context) {
+	id := correlationID(c)
+	c.Request = c.Request.WithContext(events.WithCorrelationID(c.Request.Context(), id))
+	c.Set("correlation_id", id)
+	c.Header(HeaderCorrelationID, id)
+	c.Next()
+}
+
+// CorrelationUnaryInterceptor делает то же, что Correlation, для gRPC: ID берётся из метаданных
+// с теми же именами заголовков.
+func CorrelationUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := pickCorrelationID(func(name string) string {
+		if values := md.Get(name); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	})
+	return handler(events.WithCorrelationID(ctx, id), req)
+}
+
+func correlationID(c *gin.Context) string {
+	return pickCorrelationID(c.GetHeader)
+}
+
+func pickCorrelationID(header func(name string) string) string {
+	for _, name := range []string{HeaderCorrelationID, HeaderRequestID} {
+		if id := strings.TrimSpace(header(name)); id != "" && len(id) <= maxCorrelationIDLen {
+			return id
+		}
+	}
+	// traceparent: version-traceid-parentid-flags
+	if parts := strings.Split(header(headerTraceParent), "-"); len(parts) == 4 && len(parts[1]) == 32 {
+		return parts[1]
+	}
+	return uuid.NewString()
+}
@@ -1,5 +1,11 @@
 package middleware
 
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
 type Role string
 
 const (
@@ -18,3 +24,19 @@ var RoleHierarchy = map[Role]int{
 func (r Role) CanModerate(target Role) bool {
 	return RoleHierarchy[r] > RoleHierarchy[target]
 }
+
+// RequireRole пропускает запрос, только если у пользователя есть роль не ниже required.
+// Должен стоять после AuthMiddleware, который кладёт роли в контекст.
+func RequireRole(required Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if roles, ok := c.Get("roles"); ok {
+			for _, r := range roles.([]Role) {
+				if RoleHierarchy[r] >= RoleHierarchy[required] {
+					c.Next()
+					return
+				}
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient role"})
+	}
+}
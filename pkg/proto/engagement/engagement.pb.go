@@ -0,0 +1,1032 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: pkg/proto/engagement.proto
+
+package engagementpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetLikeCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetType    string                 `protobuf:"bytes,1,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeCountRequest) Reset() {
+	*x = GetLikeCountRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeCountRequest) ProtoMessage() {}
+
+func (x *GetLikeCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeCountRequest.ProtoReflect.Descriptor instead.
+func (*GetLikeCountRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetLikeCountRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *GetLikeCountRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type GetLikeCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LikeCount     int64                  `protobuf:"varint,1,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeCountResponse) Reset() {
+	*x = GetLikeCountResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeCountResponse) ProtoMessage() {}
+
+func (x *GetLikeCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeCountResponse.ProtoReflect.Descriptor instead.
+func (*GetLikeCountResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLikeCountResponse) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+type GetLikeCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetType    string                 `protobuf:"bytes,1,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetIds     []string               `protobuf:"bytes,2,rep,name=target_ids,json=targetIds,proto3" json:"target_ids,omitempty"` // at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeCountsRequest) Reset() {
+	*x = GetLikeCountsRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeCountsRequest) ProtoMessage() {}
+
+func (x *GetLikeCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetLikeCountsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLikeCountsRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *GetLikeCountsRequest) GetTargetIds() []string {
+	if x != nil {
+		return x.TargetIds
+	}
+	return nil
+}
+
+type GetLikeCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        map[string]int64       `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // keyed by target ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeCountsResponse) Reset() {
+	*x = GetLikeCountsResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeCountsResponse) ProtoMessage() {}
+
+func (x *GetLikeCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetLikeCountsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLikeCountsResponse) GetCounts() map[string]int64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type GetLikeStatusesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetType    string                 `protobuf:"bytes,2,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetIds     []string               `protobuf:"bytes,3,rep,name=target_ids,json=targetIds,proto3" json:"target_ids,omitempty"` // at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeStatusesRequest) Reset() {
+	*x = GetLikeStatusesRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeStatusesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeStatusesRequest) ProtoMessage() {}
+
+func (x *GetLikeStatusesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeStatusesRequest.ProtoReflect.Descriptor instead.
+func (*GetLikeStatusesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLikeStatusesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLikeStatusesRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *GetLikeStatusesRequest) GetTargetIds() []string {
+	if x != nil {
+		return x.TargetIds
+	}
+	return nil
+}
+
+type LikeStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Liked         bool                   `protobuf:"varint,1,opt,name=liked,proto3" json:"liked,omitempty"`
+	LikeId        *string                `protobuf:"bytes,2,opt,name=like_id,json=likeId,proto3,oneof" json:"like_id,omitempty"` // set when liked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeStatus) Reset() {
+	*x = LikeStatus{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeStatus) ProtoMessage() {}
+
+func (x *LikeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeStatus.ProtoReflect.Descriptor instead.
+func (*LikeStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LikeStatus) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *LikeStatus) GetLikeId() string {
+	if x != nil && x.LikeId != nil {
+		return *x.LikeId
+	}
+	return ""
+}
+
+type GetLikeStatusesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      map[string]*LikeStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // keyed by target ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeStatusesResponse) Reset() {
+	*x = GetLikeStatusesResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeStatusesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeStatusesResponse) ProtoMessage() {}
+
+func (x *GetLikeStatusesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeStatusesResponse.ProtoReflect.Descriptor instead.
+func (*GetLikeStatusesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLikeStatusesResponse) GetStatuses() map[string]*LikeStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type GetFollowCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowCountsRequest) Reset() {
+	*x = GetFollowCountsRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowCountsRequest) ProtoMessage() {}
+
+func (x *GetFollowCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowCountsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetFollowCountsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetFollowCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Followers     int64                  `protobuf:"varint,1,opt,name=followers,proto3" json:"followers,omitempty"`
+	Following     int64                  `protobuf:"varint,2,opt,name=following,proto3" json:"following,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowCountsResponse) Reset() {
+	*x = GetFollowCountsResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowCountsResponse) ProtoMessage() {}
+
+func (x *GetFollowCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowCountsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetFollowCountsResponse) GetFollowers() int64 {
+	if x != nil {
+		return x.Followers
+	}
+	return 0
+}
+
+func (x *GetFollowCountsResponse) GetFollowing() int64 {
+	if x != nil {
+		return x.Following
+	}
+	return 0
+}
+
+type IsFollowingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	FolloweeId    string                 `protobuf:"bytes,2,opt,name=followee_id,json=followeeId,proto3" json:"followee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsFollowingRequest) Reset() {
+	*x = IsFollowingRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingRequest) ProtoMessage() {}
+
+func (x *IsFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingRequest.ProtoReflect.Descriptor instead.
+func (*IsFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IsFollowingRequest) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *IsFollowingRequest) GetFolloweeId() string {
+	if x != nil {
+		return x.FolloweeId
+	}
+	return ""
+}
+
+type IsFollowingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Following     bool                   `protobuf:"varint,1,opt,name=following,proto3" json:"following,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsFollowingResponse) Reset() {
+	*x = IsFollowingResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsFollowingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingResponse) ProtoMessage() {}
+
+func (x *IsFollowingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingResponse.ProtoReflect.Descriptor instead.
+func (*IsFollowingResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IsFollowingResponse) GetFollowing() bool {
+	if x != nil {
+		return x.Following
+	}
+	return false
+}
+
+type GetFollowerIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // defaults to 20, at most 100
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowerIdsRequest) Reset() {
+	*x = GetFollowerIdsRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowerIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowerIdsRequest) ProtoMessage() {}
+
+func (x *GetFollowerIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowerIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowerIdsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetFollowerIdsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFollowerIdsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetFollowerIdsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetFollowerIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerIds   []string               `protobuf:"bytes,1,rep,name=follower_ids,json=followerIds,proto3" json:"follower_ids,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowerIdsResponse) Reset() {
+	*x = GetFollowerIdsResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowerIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowerIdsResponse) ProtoMessage() {}
+
+func (x *GetFollowerIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowerIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowerIdsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetFollowerIdsResponse) GetFollowerIds() []string {
+	if x != nil {
+		return x.FollowerIds
+	}
+	return nil
+}
+
+func (x *GetFollowerIdsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetFollowerIdsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetMutedIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMutedIdsRequest) Reset() {
+	*x = GetMutedIdsRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMutedIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMutedIdsRequest) ProtoMessage() {}
+
+func (x *GetMutedIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMutedIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetMutedIdsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetMutedIdsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetMutedIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MutedIds      []string               `protobuf:"bytes,1,rep,name=muted_ids,json=mutedIds,proto3" json:"muted_ids,omitempty"` // active mutes only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMutedIdsResponse) Reset() {
+	*x = GetMutedIdsResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMutedIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMutedIdsResponse) ProtoMessage() {}
+
+func (x *GetMutedIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMutedIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetMutedIdsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetMutedIdsResponse) GetMutedIds() []string {
+	if x != nil {
+		return x.MutedIds
+	}
+	return nil
+}
+
+type IsCloseFriendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the viewer; owners count as their own close friends
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsCloseFriendRequest) Reset() {
+	*x = IsCloseFriendRequest{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsCloseFriendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsCloseFriendRequest) ProtoMessage() {}
+
+func (x *IsCloseFriendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsCloseFriendRequest.ProtoReflect.Descriptor instead.
+func (*IsCloseFriendRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *IsCloseFriendRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *IsCloseFriendRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type IsCloseFriendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CloseFriend   bool                   `protobuf:"varint,1,opt,name=close_friend,json=closeFriend,proto3" json:"close_friend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsCloseFriendResponse) Reset() {
+	*x = IsCloseFriendResponse{}
+	mi := &file_pkg_proto_engagement_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsCloseFriendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsCloseFriendResponse) ProtoMessage() {}
+
+func (x *IsCloseFriendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_engagement_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsCloseFriendResponse.ProtoReflect.Descriptor instead.
+func (*IsCloseFriendResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_engagement_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IsCloseFriendResponse) GetCloseFriend() bool {
+	if x != nil {
+		return x.CloseFriend
+	}
+	return false
+}
+
+var File_pkg_proto_engagement_proto protoreflect.FileDescriptor
+
+const file_pkg_proto_engagement_proto_rawDesc = "" +
+	"\n" +
+	"\x1apkg/proto/engagement.proto\x12\n" +
+	"engagement\"S\n" +
+	"\x13GetLikeCountRequest\x12\x1f\n" +
+	"\vtarget_type\x18\x01 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"5\n" +
+	"\x14GetLikeCountResponse\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x01 \x01(\x03R\tlikeCount\"V\n" +
+	"\x14GetLikeCountsRequest\x12\x1f\n" +
+	"\vtarget_type\x18\x01 \x01(\tR\n" +
+	"targetType\x12\x1d\n" +
+	"\n" +
+	"target_ids\x18\x02 \x03(\tR\ttargetIds\"\x99\x01\n" +
+	"\x15GetLikeCountsResponse\x12E\n" +
+	"\x06counts\x18\x01 \x03(\v2-.engagement.GetLikeCountsResponse.CountsEntryR\x06counts\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"q\n" +
+	"\x16GetLikeStatusesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vtarget_type\x18\x02 \x01(\tR\n" +
+	"targetType\x12\x1d\n" +
+	"\n" +
+	"target_ids\x18\x03 \x03(\tR\ttargetIds\"L\n" +
+	"\n" +
+	"LikeStatus\x12\x14\n" +
+	"\x05liked\x18\x01 \x01(\bR\x05liked\x12\x1c\n" +
+	"\alike_id\x18\x02 \x01(\tH\x00R\x06likeId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_like_id\"\xbd\x01\n" +
+	"\x17GetLikeStatusesResponse\x12M\n" +
+	"\bstatuses\x18\x01 \x03(\v21.engagement.GetLikeStatusesResponse.StatusesEntryR\bstatuses\x1aS\n" +
+	"\rStatusesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.engagement.LikeStatusR\x05value:\x028\x01\"1\n" +
+	"\x16GetFollowCountsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"U\n" +
+	"\x17GetFollowCountsResponse\x12\x1c\n" +
+	"\tfollowers\x18\x01 \x01(\x03R\tfollowers\x12\x1c\n" +
+	"\tfollowing\x18\x02 \x01(\x03R\tfollowing\"V\n" +
+	"\x12IsFollowingRequest\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x1f\n" +
+	"\vfollowee_id\x18\x02 \x01(\tR\n" +
+	"followeeId\"3\n" +
+	"\x13IsFollowingResponse\x12\x1c\n" +
+	"\tfollowing\x18\x01 \x01(\bR\tfollowing\"^\n" +
+	"\x15GetFollowerIdsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"w\n" +
+	"\x16GetFollowerIdsResponse\x12!\n" +
+	"\ffollower_ids\x18\x01 \x03(\tR\vfollowerIds\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"-\n" +
+	"\x12GetMutedIdsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"2\n" +
+	"\x13GetMutedIdsResponse\x12\x1b\n" +
+	"\tmuted_ids\x18\x01 \x03(\tR\bmutedIds\"J\n" +
+	"\x14IsCloseFriendRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\":\n" +
+	"\x15IsCloseFriendResponse\x12!\n" +
+	"\fclose_friend\x18\x01 \x01(\bR\vcloseFriend2\xc3\x05\n" +
+	"\x11EngagementService\x12Q\n" +
+	"\fGetLikeCount\x12\x1f.engagement.GetLikeCountRequest\x1a .engagement.GetLikeCountResponse\x12T\n" +
+	"\rGetLikeCounts\x12 .engagement.GetLikeCountsRequest\x1a!.engagement.GetLikeCountsResponse\x12Z\n" +
+	"\x0fGetLikeStatuses\x12\".engagement.GetLikeStatusesRequest\x1a#.engagement.GetLikeStatusesResponse\x12Z\n" +
+	"\x0fGetFollowCounts\x12\".engagement.GetFollowCountsRequest\x1a#.engagement.GetFollowCountsResponse\x12N\n" +
+	"\vIsFollowing\x12\x1e.engagement.IsFollowingRequest\x1a\x1f.engagement.IsFollowingResponse\x12W\n" +
+	"\x0eGetFollowerIds\x12!.engagement.GetFollowerIdsRequest\x1a\".engagement.GetFollowerIdsResponse\x12N\n" +
+	"\vGetMutedIds\x12\x1e.engagement.GetMutedIdsRequest\x1a\x1f.engagement.GetMutedIdsResponse\x12T\n" +
+	"\rIsCloseFriend\x12 .engagement.IsCloseFriendRequest\x1a!.engagement.IsCloseFriendResponseB$Z\"/pkg/proto/engagement;engagementpbb\x06proto3"
+
+var (
+	file_pkg_proto_engagement_proto_rawDescOnce sync.Once
+	file_pkg_proto_engagement_proto_rawDescData []byte
+)
+
+func file_pkg_proto_engagement_proto_rawDescGZIP() []byte {
+	file_pkg_proto_engagement_proto_rawDescOnce.Do(func() {
+		file_pkg_proto_engagement_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_proto_engagement_proto_rawDesc), len(file_pkg_proto_engagement_proto_rawDesc)))
+	})
+	return file_pkg_proto_engagement_proto_rawDescData
+}
+
+var file_pkg_proto_engagement_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_pkg_proto_engagement_proto_goTypes = []any{
+	(*GetLikeCountRequest)(nil),     // 0: engagement.GetLikeCountRequest
+	(*GetLikeCountResponse)(nil),    // 1: engagement.GetLikeCountResponse
+	(*GetLikeCountsRequest)(nil),    // 2: engagement.GetLikeCountsRequest
+	(*GetLikeCountsResponse)(nil),   // 3: engagement.GetLikeCountsResponse
+	(*GetLikeStatusesRequest)(nil),  // 4: engagement.GetLikeStatusesRequest
+	(*LikeStatus)(nil),              // 5: engagement.LikeStatus
+	(*GetLikeStatusesResponse)(nil), // 6: engagement.GetLikeStatusesResponse
+	(*GetFollowCountsRequest)(nil),  // 7: engagement.GetFollowCountsRequest
+	(*GetFollowCountsResponse)(nil), // 8: engagement.GetFollowCountsResponse
+	(*IsFollowingRequest)(nil),      // 9: engagement.IsFollowingRequest
+	(*IsFollowingResponse)(nil),     // 10: engagement.IsFollowingResponse
+	(*GetFollowerIdsRequest)(nil),   // 11: engagement.GetFollowerIdsRequest
+	(*GetFollowerIdsResponse)(nil),  // 12: engagement.GetFollowerIdsResponse
+	(*GetMutedIdsRequest)(nil),      // 13: engagement.GetMutedIdsRequest
+	(*GetMutedIdsResponse)(nil),     // 14: engagement.GetMutedIdsResponse
+	(*IsCloseFriendRequest)(nil),    // 15: engagement.IsCloseFriendRequest
+	(*IsCloseFriendResponse)(nil),   // 16: engagement.IsCloseFriendResponse
+	nil,                             // 17: engagement.GetLikeCountsResponse.CountsEntry
+	nil,                             // 18: engagement.GetLikeStatusesResponse.StatusesEntry
+}
+var file_pkg_proto_engagement_proto_depIdxs = []int32{
+	17, // 0: engagement.GetLikeCountsResponse.counts:type_name -> engagement.GetLikeCountsResponse.CountsEntry
+	18, // 1: engagement.GetLikeStatusesResponse.statuses:type_name -> engagement.GetLikeStatusesResponse.StatusesEntry
+	5,  // 2: engagement.GetLikeStatusesResponse.StatusesEntry.value:type_name -> engagement.LikeStatus
+	0,  // 3: engagement.EngagementService.GetLikeCount:input_type -> engagement.GetLikeCountRequest
+	2,  // 4: engagement.EngagementService.GetLikeCounts:input_type -> engagement.GetLikeCountsRequest
+	4,  // 5: engagement.EngagementService.GetLikeStatuses:input_type -> engagement.GetLikeStatusesRequest
+	7,  // 6: engagement.EngagementService.GetFollowCounts:input_type -> engagement.GetFollowCountsRequest
+	9,  // 7: engagement.EngagementService.IsFollowing:input_type -> engagement.IsFollowingRequest
+	11, // 8: engagement.EngagementService.GetFollowerIds:input_type -> engagement.GetFollowerIdsRequest
+	13, // 9: engagement.EngagementService.GetMutedIds:input_type -> engagement.GetMutedIdsRequest
+	15, // 10: engagement.EngagementService.IsCloseFriend:input_type -> engagement.IsCloseFriendRequest
+	1,  // 11: engagement.EngagementService.GetLikeCount:output_type -> engagement.GetLikeCountResponse
+	3,  // 12: engagement.EngagementService.GetLikeCounts:output_type -> engagement.GetLikeCountsResponse
+	6,  // 13: engagement.EngagementService.GetLikeStatuses:output_type -> engagement.GetLikeStatusesResponse
+	8,  // 14: engagement.EngagementService.GetFollowCounts:output_type -> engagement.GetFollowCountsResponse
+	10, // 15: engagement.EngagementService.IsFollowing:output_type -> engagement.IsFollowingResponse
+	12, // 16: engagement.EngagementService.GetFollowerIds:output_type -> engagement.GetFollowerIdsResponse
+	14, // 17: engagement.EngagementService.GetMutedIds:output_type -> engagement.GetMutedIdsResponse
+	16, // 18: engagement.EngagementService.IsCloseFriend:output_type -> engagement.IsCloseFriendResponse
+	11, // [11:19] is the sub-list for method output_type
+	3,  // [3:11] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_pkg_proto_engagement_proto_init() }
+func file_pkg_proto_engagement_proto_init() {
+	if File_pkg_proto_engagement_proto != nil {
+		return
+	}
+	file_pkg_proto_engagement_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_proto_engagement_proto_rawDesc), len(file_pkg_proto_engagement_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_proto_engagement_proto_goTypes,
+		DependencyIndexes: file_pkg_proto_engagement_proto_depIdxs,
+		MessageInfos:      file_pkg_proto_engagement_proto_msgTypes,
+	}.Build()
+	File_pkg_proto_engagement_proto = out.File
+	file_pkg_proto_engagement_proto_goTypes = nil
+	file_pkg_proto_engagement_proto_depIdxs = nil
+}
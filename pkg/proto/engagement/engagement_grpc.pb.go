@@ -0,0 +1,401 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: pkg/proto/engagement.proto
+
+package engagementpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EngagementService_GetLikeCount_FullMethodName    = "/engagement.EngagementService/GetLikeCount"
+	EngagementService_GetLikeCounts_FullMethodName   = "/engagement.EngagementService/GetLikeCounts"
+	EngagementService_GetLikeStatuses_FullMethodName = "/engagement.EngagementService/GetLikeStatuses"
+	EngagementService_GetFollowCounts_FullMethodName = "/engagement.EngagementService/GetFollowCounts"
+	EngagementService_IsFollowing_FullMethodName     = "/engagement.EngagementService/IsFollowing"
+	EngagementService_GetFollowerIds_FullMethodName  = "/engagement.EngagementService/GetFollowerIds"
+	EngagementService_GetMutedIds_FullMethodName     = "/engagement.EngagementService/GetMutedIds"
+	EngagementService_IsCloseFriend_FullMethodName   = "/engagement.EngagementService/IsCloseFriend"
+)
+
+// EngagementServiceClient is the client API for EngagementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EngagementService exposes likes and subscriptions to other services.
+// Target types are the ones the REST API accepts ("post", "comment", ...); an empty one means "post".
+type EngagementServiceClient interface {
+	// Likes
+	GetLikeCount(ctx context.Context, in *GetLikeCountRequest, opts ...grpc.CallOption) (*GetLikeCountResponse, error)
+	GetLikeCounts(ctx context.Context, in *GetLikeCountsRequest, opts ...grpc.CallOption) (*GetLikeCountsResponse, error)
+	GetLikeStatuses(ctx context.Context, in *GetLikeStatusesRequest, opts ...grpc.CallOption) (*GetLikeStatusesResponse, error)
+	// Subscriptions
+	GetFollowCounts(ctx context.Context, in *GetFollowCountsRequest, opts ...grpc.CallOption) (*GetFollowCountsResponse, error)
+	IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error)
+	GetFollowerIds(ctx context.Context, in *GetFollowerIdsRequest, opts ...grpc.CallOption) (*GetFollowerIdsResponse, error)
+	// Mutes
+	GetMutedIds(ctx context.Context, in *GetMutedIdsRequest, opts ...grpc.CallOption) (*GetMutedIdsResponse, error)
+	// Lists
+	IsCloseFriend(ctx context.Context, in *IsCloseFriendRequest, opts ...grpc.CallOption) (*IsCloseFriendResponse, error)
+}
+
+type engagementServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEngagementServiceClient(cc grpc.ClientConnInterface) EngagementServiceClient {
+	return &engagementServiceClient{cc}
+}
+
+func (c *engagementServiceClient) GetLikeCount(ctx context.Context, in *GetLikeCountRequest, opts ...grpc.CallOption) (*GetLikeCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLikeCountResponse)
+	err := c.cc.Invoke(ctx, EngagementService_GetLikeCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engagementServiceClient) GetLikeCounts(ctx context.Context, in *GetLikeCountsRequest, opts ...grpc.CallOption) (*GetLikeCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLikeCountsResponse)
+	err := c.cc.Invoke(ctx, EngagementService_GetLikeCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engagementServiceClient) GetLikeStatuses(ctx context.Context, in *GetLikeStatusesRequest, opts ...grpc.CallOption) (*GetLikeStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLikeStatusesResponse)
+	err := c.cc.Invoke(ctx, EngagementService_GetLikeStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engagementServiceClient) GetFollowCounts(ctx context.Context, in *GetFollowCountsRequest, opts ...grpc.CallOption) (*GetFollowCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowCountsResponse)
+	err := c.cc.Invoke(ctx, EngagementService_GetFollowCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engagementServiceClient) IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsFollowingResponse)
+	err := c.cc.Invoke(ctx, EngagementService_IsFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engagementServiceClient) GetFollowerIds(ctx context.Context, in *GetFollowerIdsRequest, opts ...grpc.CallOption) (*GetFollowerIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowerIdsResponse)
+	err := c.cc.Invoke(ctx, EngagementService_GetFollowerIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engagementServiceClient) GetMutedIds(ctx context.Context, in *GetMutedIdsRequest, opts ...grpc.CallOption) (*GetMutedIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMutedIdsResponse)
+	err := c.cc.Invoke(ctx, EngagementService_GetMutedIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engagementServiceClient) IsCloseFriend(ctx context.Context, in *IsCloseFriendRequest, opts ...grpc.CallOption) (*IsCloseFriendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsCloseFriendResponse)
+	err := c.cc.Invoke(ctx, EngagementService_IsCloseFriend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EngagementServiceServer is the server API for EngagementService service.
+// All implementations must embed UnimplementedEngagementServiceServer
+// for forward compatibility.
+//
+// EngagementService exposes likes and subscriptions to other services.
+// Target types are the ones the REST API accepts ("post", "comment", ...); an empty one means "post".
+type EngagementServiceServer interface {
+	// Likes
+	GetLikeCount(context.Context, *GetLikeCountRequest) (*GetLikeCountResponse, error)
+	GetLikeCounts(context.Context, *GetLikeCountsRequest) (*GetLikeCountsResponse, error)
+	GetLikeStatuses(context.Context, *GetLikeStatusesRequest) (*GetLikeStatusesResponse, error)
+	// Subscriptions
+	GetFollowCounts(context.Context, *GetFollowCountsRequest) (*GetFollowCountsResponse, error)
+	IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error)
+	GetFollowerIds(context.Context, *GetFollowerIdsRequest) (*GetFollowerIdsResponse, error)
+	// Mutes
+	GetMutedIds(context.Context, *GetMutedIdsRequest) (*GetMutedIdsResponse, error)
+	// Lists
+	IsCloseFriend(context.Context, *IsCloseFriendRequest) (*IsCloseFriendResponse, error)
+	mustEmbedUnimplementedEngagementServiceServer()
+}
+
+// UnimplementedEngagementServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEngagementServiceServer struct{}
+
+func (UnimplementedEngagementServiceServer) GetLikeCount(context.Context, *GetLikeCountRequest) (*GetLikeCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLikeCount not implemented")
+}
+func (UnimplementedEngagementServiceServer) GetLikeCounts(context.Context, *GetLikeCountsRequest) (*GetLikeCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLikeCounts not implemented")
+}
+func (UnimplementedEngagementServiceServer) GetLikeStatuses(context.Context, *GetLikeStatusesRequest) (*GetLikeStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLikeStatuses not implemented")
+}
+func (UnimplementedEngagementServiceServer) GetFollowCounts(context.Context, *GetFollowCountsRequest) (*GetFollowCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowCounts not implemented")
+}
+func (UnimplementedEngagementServiceServer) IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsFollowing not implemented")
+}
+func (UnimplementedEngagementServiceServer) GetFollowerIds(context.Context, *GetFollowerIdsRequest) (*GetFollowerIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowerIds not implemented")
+}
+func (UnimplementedEngagementServiceServer) GetMutedIds(context.Context, *GetMutedIdsRequest) (*GetMutedIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMutedIds not implemented")
+}
+func (UnimplementedEngagementServiceServer) IsCloseFriend(context.Context, *IsCloseFriendRequest) (*IsCloseFriendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsCloseFriend not implemented")
+}
+func (UnimplementedEngagementServiceServer) mustEmbedUnimplementedEngagementServiceServer() {}
+func (UnimplementedEngagementServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeEngagementServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EngagementServiceServer will
+// result in compilation errors.
+type UnsafeEngagementServiceServer interface {
+	mustEmbedUnimplementedEngagementServiceServer()
+}
+
+func RegisterEngagementServiceServer(s grpc.ServiceRegistrar, srv EngagementServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEngagementServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EngagementService_ServiceDesc, srv)
+}
+
+func _EngagementService_GetLikeCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLikeCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).GetLikeCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_GetLikeCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).GetLikeCount(ctx, req.(*GetLikeCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngagementService_GetLikeCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLikeCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).GetLikeCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_GetLikeCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).GetLikeCounts(ctx, req.(*GetLikeCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngagementService_GetLikeStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLikeStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).GetLikeStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_GetLikeStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).GetLikeStatuses(ctx, req.(*GetLikeStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngagementService_GetFollowCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).GetFollowCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_GetFollowCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).GetFollowCounts(ctx, req.(*GetFollowCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngagementService_IsFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsFollowingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).IsFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_IsFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).IsFollowing(ctx, req.(*IsFollowingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngagementService_GetFollowerIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowerIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).GetFollowerIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_GetFollowerIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).GetFollowerIds(ctx, req.(*GetFollowerIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngagementService_GetMutedIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMutedIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).GetMutedIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_GetMutedIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).GetMutedIds(ctx, req.(*GetMutedIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngagementService_IsCloseFriend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsCloseFriendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngagementServiceServer).IsCloseFriend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngagementService_IsCloseFriend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngagementServiceServer).IsCloseFriend(ctx, req.(*IsCloseFriendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EngagementService_ServiceDesc is the grpc.ServiceDesc for EngagementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EngagementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "engagement.EngagementService",
+	HandlerType: (*EngagementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLikeCount",
+			Handler:    _EngagementService_GetLikeCount_Handler,
+		},
+		{
+			MethodName: "GetLikeCounts",
+			Handler:    _EngagementService_GetLikeCounts_Handler,
+		},
+		{
+			MethodName: "GetLikeStatuses",
+			Handler:    _EngagementService_GetLikeStatuses_Handler,
+		},
+		{
+			MethodName: "GetFollowCounts",
+			Handler:    _EngagementService_GetFollowCounts_Handler,
+		},
+		{
+			MethodName: "IsFollowing",
+			Handler:    _EngagementService_IsFollowing_Handler,
+		},
+		{
+			MethodName: "GetFollowerIds",
+			Handler:    _EngagementService_GetFollowerIds_Handler,
+		},
+		{
+			MethodName: "GetMutedIds",
+			Handler:    _EngagementService_GetMutedIds_Handler,
+		},
+		{
+			MethodName: "IsCloseFriend",
+			Handler:    _EngagementService_IsCloseFriend_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/proto/engagement.proto",
+}
@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
 	"engagementService/internal/bootstrap"
+	"engagementService/internal/delivery/rpc"
 	"engagementService/internal/router"
 	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/Sayan80bayev/go-project/pkg/middleware"
+	engagementpb "github.com/Sayan80bayev/go-project/pkg/proto/engagement"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"net"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
@@ -13,20 +21,60 @@ func main() {
 		panic(err)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go ctn.OutboxRelay.Start(ctx)
+	go ctn.LikeCountReconciler.Start(ctx)
+	go ctn.TrendingBackfill.Start(ctx)
+	go ctn.MuteExpirer.Start(ctx)
+	go ctn.SuggestionRefresher.Start(ctx)
+	go ctn.FollowImporter.Start(ctx)
+	go serveGRPC(ctx, ctn)
+
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
 	r.Use(logging.Middleware)
+	r.Use(middleware.Correlation)
 	SetupRoutes(r, ctn)
 
 	err = r.Run(":" + ctn.Config.Port)
 	if err != nil {
 		panic(err)
-		return
+	}
+}
+
+// serveGRPC runs the internal gRPC API next to the HTTP server and stops it gracefully on shutdown.
+func serveGRPC(ctx context.Context, ctn *bootstrap.Container) {
+	logger := logging.GetLogger()
+
+	lis, err := net.Listen("tcp", ":"+ctn.Config.GRPCPort)
+	if err != nil {
+		logger.Fatalf("failed to listen on gRPC port %s: %v", ctn.Config.GRPCPort, err)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(middleware.CorrelationUnaryInterceptor))
+	engagementpb.RegisterEngagementServiceServer(srv, rpc.NewEngagementServer(ctn.LikeService, ctn.SubscriptionService, ctn.MuteService, ctn.ListService))
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	logger.Infof("gRPC server listening on :%s", ctn.Config.GRPCPort)
+	if err := srv.Serve(lis); err != nil {
+		logger.Fatalf("gRPC server stopped: %v", err)
 	}
 }
 
 func SetupRoutes(r *gin.Engine, ctn *bootstrap.Container) {
 	router.SetupSubscriptionRoutes(r, ctn)
 	router.SetupLikeRoutes(r, ctn)
+	router.SetupTrendingRoutes(r, ctn)
+	router.SetupBlockRoutes(r, ctn)
+	router.SetupMuteRoutes(r, ctn)
+	router.SetupSuggestionRoutes(r, ctn)
+	router.SetupListRoutes(r, ctn)
+	router.SetupAdminRoutes(r, ctn)
 }
@@ -7,6 +7,7 @@ import (
 	ms "engagementService/internal/messaging"
 	"engagementService/internal/repository"
 	"engagementService/internal/service"
+	"engagementService/internal/worker"
 	"fmt"
 	"github.com/Sayan80bayev/go-project/pkg/caching"
 	"github.com/Sayan80bayev/go-project/pkg/logging"
@@ -25,7 +26,19 @@ type Container struct {
 	Producer            messaging.Producer
 	Consumer            messaging.Consumer
 	SubscriptionService *service.SubscriptionService
+	BlockService        *service.BlockService
+	MuteService         *service.MuteService
+	ListService         *service.ListService
+	FollowImportService *service.FollowImportService
+	SuggestionService   *service.SuggestionService
 	LikeService         *service.LikeService
+	TrendingService     *service.TrendingService
+	OutboxRelay         *worker.OutboxRelay
+	LikeCountReconciler *worker.LikeCountReconciler
+	TrendingBackfill    *worker.TrendingBackfill
+	MuteExpirer         *worker.MuteExpirer
+	SuggestionRefresher *worker.SuggestionRefresher
+	FollowImporter      *worker.FollowImporter
 	Config              *config.Config
 	JWKSUrl             string
 }
@@ -55,17 +68,58 @@ func Init() (*Container, error) {
 		return nil, err
 	}
 
-	producer, err := initRabbitMQProducer(cfg)
+	producer, err := initProducer(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+		return nil, fmt.Errorf("failed to create producer: %w", err)
 	}
 
+	outboxRepo := repository.NewPostgresOutboxRepo(db)
+	rateLimitService := service.NewRateLimitService(caching.NewRedisRateLimiter(cacheService, "rate:"), outboxRepo, map[string]service.ActionLimits{
+		service.ActionFollow:       {PerMinute: cfg.FollowRatePerMinute, PerDay: cfg.FollowRatePerDay},
+		service.ActionLike:         {PerMinute: cfg.LikeRatePerMinute, PerDay: cfg.LikeRatePerDay},
+		service.ActionFollowImport: {PerMinute: cfg.FollowImportRatePerMinute, PerDay: cfg.FollowImportRatePerDay},
+		service.ActionUnfollow:     {PerMinute: cfg.UnfollowRatePerMinute, PerDay: cfg.UnfollowRatePerDay},
+	})
+
 	// Use the new PostgresSubscriptionRepo
 	subRepo := repository.NewPostgresSubscriptionRepo(db) // Changed to NewPostgresSubscriptionRepo
-	subService := service.NewSubscriptionService(subRepo, producer)
+	privacyRepo := repository.NewPostgresPrivacyRepo(db)
+	blockRepo := repository.NewPostgresBlockRepo(db)
+	muteRepo := repository.NewPostgresMuteRepo(db)
+	followStatsService := service.NewFollowStatsService(repository.NewPostgresFollowStatsRepo(db), cacheService)
+	subService := service.NewSubscriptionService(subRepo, privacyRepo, blockRepo, muteRepo, followStatsService, repository.NewPostgresRelationshipEventRepo(db), rateLimitService)
+	blockService := service.NewBlockService(blockRepo, followStatsService)
+	muteService := service.NewMuteService(muteRepo)
+	muteExpirer := worker.NewMuteExpirer(muteService, cfg.MuteExpiryInterval)
+	followImportService := service.NewFollowImportService(repository.NewPostgresFollowImportRepo(db), subRepo, followStatsService, rateLimitService, service.FollowImportServiceConfig{
+		BatchSize: cfg.FollowImportBatchSize,
+		MaxSize:   cfg.FollowImportMaxSize,
+	})
+	followImporter := worker.NewFollowImporter(followImportService, cfg.FollowImportPollInterval)
+	listService := service.NewListService(repository.NewPostgresListRepo(db), blockRepo)
+
+	suggestionRepo := repository.NewPostgresSuggestionRepo(db)
+	suggestionService := service.NewSuggestionService(suggestionRepo, cacheService, service.SuggestionServiceConfig{
+		CacheTTL:     cfg.SuggestionCacheTTL,
+		ActiveWindow: cfg.SuggestionActiveWindow,
+		RefreshUsers: cfg.SuggestionRefreshUsers,
+	})
+	suggestionRefresher := worker.NewSuggestionRefresher(suggestionService, cfg.SuggestionRefreshInterval)
 
 	likeRepo := repository.NewPostgresLikeRepo(db)
-	likeService := service.NewLikeService(likeRepo)
+	trendingService := service.NewTrendingService(likeRepo, cacheService)
+	likeService := service.NewLikeService(likeRepo, blockRepo, cacheService, trendingService, rateLimitService, service.LikeServiceConfig{
+		StatusCacheTTL: cfg.LikeStatusCacheTTL,
+		ExtraReactions: cfg.ExtraReactions,
+	})
+	likeCountReconciler := worker.NewLikeCountReconciler(likeService, cfg.LikeCountReconcileInterval)
+	trendingBackfill := worker.NewTrendingBackfill(trendingService, cfg.TrendingBackfillInterval)
+
+	outboxRelay := worker.NewOutboxRelay(outboxRepo, producer, worker.OutboxRelayConfig{
+		PollInterval: cfg.OutboxPollInterval,
+		BatchSize:    cfg.OutboxBatchSize,
+		MaxAttempts:  cfg.OutboxMaxAttempts,
+	})
 
 	jwksURL := buildJWKSURL(cfg)
 
@@ -78,7 +132,19 @@ func Init() (*Container, error) {
 		Config:              cfg,
 		JWKSUrl:             jwksURL,
 		SubscriptionService: subService,
+		BlockService:        blockService,
+		MuteService:         muteService,
+		ListService:         listService,
+		SuggestionService:   suggestionService,
 		LikeService:         likeService,
+		TrendingService:     trendingService,
+		OutboxRelay:         outboxRelay,
+		LikeCountReconciler: likeCountReconciler,
+		TrendingBackfill:    trendingBackfill,
+		MuteExpirer:         muteExpirer,
+		SuggestionRefresher: suggestionRefresher,
+		FollowImportService: followImportService,
+		FollowImporter:      followImporter,
 	}, nil
 }
 
@@ -153,10 +219,26 @@ func initRedis(cfg *config.Config) (*caching.RedisService, error) {
 	return redisCache, nil
 }
 
+// eventSource names this service in the envelope of the events it produces
+const eventSource = "engagement-service"
+
+func initProducer(cfg *config.Config) (messaging.Producer, error) {
+	switch cfg.MessagingDriver {
+	case "memory":
+		// Events stay in-process; nothing consumes them, which is enough to run the service locally
+		logging.GetLogger().Warn("MESSAGING_DRIVER=memory: events are not delivered to other services")
+		return messaging.NewMemoryBroker().Producer(cfg.RabbitMQExchange, eventSource), nil
+	case "rabbitmq", "":
+		return initRabbitMQProducer(cfg)
+	default:
+		return nil, fmt.Errorf("unknown MESSAGING_DRIVER %q", cfg.MessagingDriver)
+	}
+}
+
 func initRabbitMQProducer(cfg *config.Config) (messaging.Producer, error) {
 	logger := logging.GetLogger()
 	ampq := buildAmqpURL(cfg)
-	prod, err := ms.NewRabbitProducer(ampq, cfg.RabbitMQExchange, logger)
+	prod, err := ms.NewRabbitProducer(ampq, cfg.RabbitMQExchange, eventSource, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create AMQP producer: %w", err)
 	}
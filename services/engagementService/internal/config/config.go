@@ -1,12 +1,15 @@
 package config
 
 import (
+	"fmt"
 	"github.com/Sayan80bayev/go-project/pkg/logging"
 	"github.com/spf13/viper"
+	"time"
 )
 
 type Config struct {
-	Port string `mapstructure:"PORT"`
+	Port     string `mapstructure:"PORT"`
+	GRPCPort string `mapstructure:"GRPC_PORT"`
 
 	RedisAddr string `mapstructure:"REDIS_ADDR"`
 	RedisPass string `mapstructure:"REDIS_PASS"`
@@ -20,6 +23,8 @@ type Config struct {
 	RabbitMQExchange   string `mapstructure:"RABBIT_MQ_EXCHANGE"`
 	RabbitMQRoutingKey string `mapstructure:"RABBIT_MQ_ROUTING_KEY"`
 
+	MessagingDriver string `mapstructure:"MESSAGING_DRIVER"` // "rabbitmq", or "memory" to run without a broker
+
 	KeycloakURL   string `mapstructure:"KEYCLOAK_URL"`
 	KeycloakRealm string `mapstructure:"KEYCLOAK_REALM"`
 
@@ -28,11 +33,42 @@ type Config struct {
 	PostgresUser     string `mapstructure:"POSTGRES_USER"`
 	PostgresPassword string `mapstructure:"POSTGRES_PASSWORD"`
 	PostgresDBName   string `mapstructure:"POSTGRES_DB_NAME"`
+
+	OutboxPollInterval time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxBatchSize    int           `mapstructure:"OUTBOX_BATCH_SIZE"`
+	OutboxMaxAttempts  int           `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+
+	LikeCountReconcileInterval time.Duration `mapstructure:"LIKE_COUNT_RECONCILE_INTERVAL"`
+	LikeStatusCacheTTL         time.Duration `mapstructure:"LIKE_STATUS_CACHE_TTL"` // 0 disables the cache
+	ExtraReactions             []string      `mapstructure:"EXTRA_REACTIONS"`       // comma-separated, on top of the built-in set
+
+	TrendingBackfillInterval time.Duration `mapstructure:"TRENDING_BACKFILL_INTERVAL"`
+	MuteExpiryInterval       time.Duration `mapstructure:"MUTE_EXPIRY_INTERVAL"`
+
+	SuggestionRefreshInterval time.Duration `mapstructure:"SUGGESTION_REFRESH_INTERVAL"`
+	SuggestionCacheTTL        time.Duration `mapstructure:"SUGGESTION_CACHE_TTL"`
+	SuggestionActiveWindow    time.Duration `mapstructure:"SUGGESTION_ACTIVE_WINDOW"` // follows within it make an account "recently active"
+	SuggestionRefreshUsers    int           `mapstructure:"SUGGESTION_REFRESH_USERS"` // users precomputed per refresh
+
+	FollowRatePerMinute int `mapstructure:"FOLLOW_RATE_PER_MINUTE"` // 0 disables the limit
+	FollowRatePerDay    int `mapstructure:"FOLLOW_RATE_PER_DAY"`
+	LikeRatePerMinute   int `mapstructure:"LIKE_RATE_PER_MINUTE"`
+	LikeRatePerDay      int `mapstructure:"LIKE_RATE_PER_DAY"`
+
+	FollowImportRatePerMinute int `mapstructure:"FOLLOW_IMPORT_RATE_PER_MINUTE"` // accounts followed through imports
+	FollowImportRatePerDay    int `mapstructure:"FOLLOW_IMPORT_RATE_PER_DAY"`
+	UnfollowRatePerMinute     int `mapstructure:"UNFOLLOW_RATE_PER_MINUTE"` // unfollows are only reported, never refused
+	UnfollowRatePerDay        int `mapstructure:"UNFOLLOW_RATE_PER_DAY"`
+
+	FollowImportPollInterval time.Duration `mapstructure:"FOLLOW_IMPORT_POLL_INTERVAL"`
+	FollowImportBatchSize    int           `mapstructure:"FOLLOW_IMPORT_BATCH_SIZE"` // accounts followed per transaction
+	FollowImportMaxSize      int           `mapstructure:"FOLLOW_IMPORT_MAX_SIZE"`   // accounts accepted per import
 }
 
 func LoadConfig() (*Config, error) {
 	viper.SetConfigFile("config/config.yaml")
 	viper.AutomaticEnv()
+	setDefaults()
 
 	if err := viper.ReadInConfig(); err != nil {
 		logging.Instance.Errorf("Couldn't load config.yaml: %v", err)
@@ -42,6 +78,68 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
+
+// validate rejects settings the workers can't run with, such as a ticker interval or batch size that isn't positive
+func (c *Config) validate() error {
+	intervals := map[string]time.Duration{
+		"OUTBOX_POLL_INTERVAL":          c.OutboxPollInterval,
+		"LIKE_COUNT_RECONCILE_INTERVAL": c.LikeCountReconcileInterval,
+		"TRENDING_BACKFILL_INTERVAL":    c.TrendingBackfillInterval,
+		"MUTE_EXPIRY_INTERVAL":          c.MuteExpiryInterval,
+		"SUGGESTION_REFRESH_INTERVAL":   c.SuggestionRefreshInterval,
+		"FOLLOW_IMPORT_POLL_INTERVAL":   c.FollowImportPollInterval,
+	}
+	for name, d := range intervals {
+		if d <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", name, d)
+		}
+	}
+
+	counts := map[string]int{
+		"OUTBOX_BATCH_SIZE":        c.OutboxBatchSize,
+		"OUTBOX_MAX_ATTEMPTS":      c.OutboxMaxAttempts,
+		"FOLLOW_IMPORT_BATCH_SIZE": c.FollowImportBatchSize,
+		"FOLLOW_IMPORT_MAX_SIZE":   c.FollowImportMaxSize,
+	}
+	for name, n := range counts {
+		if n < 1 {
+			return fmt.Errorf("%s must be at least 1, got %d", name, n)
+		}
+	}
+	return nil
+}
+
+// setDefaults registers values for optional settings, so they can be overridden by env but don't have to be
+func setDefaults() {
+	viper.SetDefault("GRPC_PORT", "50051")
+	viper.SetDefault("MESSAGING_DRIVER", "rabbitmq")
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", "1s")
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX_MAX_ATTEMPTS", 10)
+	viper.SetDefault("LIKE_COUNT_RECONCILE_INTERVAL", "10m")
+	viper.SetDefault("LIKE_STATUS_CACHE_TTL", "15m")
+	viper.SetDefault("EXTRA_REACTIONS", "")
+	viper.SetDefault("TRENDING_BACKFILL_INTERVAL", "6h")
+	viper.SetDefault("MUTE_EXPIRY_INTERVAL", "1m")
+	viper.SetDefault("SUGGESTION_REFRESH_INTERVAL", "1h")
+	viper.SetDefault("SUGGESTION_CACHE_TTL", "6h")
+	viper.SetDefault("SUGGESTION_ACTIVE_WINDOW", "168h")
+	viper.SetDefault("SUGGESTION_REFRESH_USERS", 1000)
+	viper.SetDefault("FOLLOW_RATE_PER_MINUTE", 30)
+	viper.SetDefault("FOLLOW_RATE_PER_DAY", 500)
+	viper.SetDefault("LIKE_RATE_PER_MINUTE", 60)
+	viper.SetDefault("LIKE_RATE_PER_DAY", 2000)
+	viper.SetDefault("FOLLOW_IMPORT_RATE_PER_MINUTE", 1000)
+	viper.SetDefault("FOLLOW_IMPORT_RATE_PER_DAY", 10000) // one full import a day
+	viper.SetDefault("UNFOLLOW_RATE_PER_MINUTE", 30)
+	viper.SetDefault("UNFOLLOW_RATE_PER_DAY", 500)
+	viper.SetDefault("FOLLOW_IMPORT_POLL_INTERVAL", "2s")
+	viper.SetDefault("FOLLOW_IMPORT_BATCH_SIZE", 500)
+	viper.SetDefault("FOLLOW_IMPORT_MAX_SIZE", 10000)
+}
@@ -0,0 +1,69 @@
+package delivery
+
+import (
+	"context"
+	"engagementService/internal/model"
+	"engagementService/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultEventsWindow is how far back the relationship history goes when ?from= is omitted
+const defaultEventsWindow = 30 * 24 * time.Hour
+
+// AdminHandler serves routes for administrators only; the router guards them by role.
+type AdminHandler struct {
+	subs *service.SubscriptionService
+}
+
+func NewAdminHandler(subs *service.SubscriptionService) *AdminHandler {
+	return &AdminHandler{subs: subs}
+}
+
+// GetRelationshipEvents GET api/v1/admin/relationships/:userId/events?from=&to=&action=follow,block&limit=20&cursor=
+// from and to are RFC3339; they default to the last 30 days.
+func (h *AdminHandler) GetRelationshipEvents(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	q := model.RelationshipEventQuery{UserID: userID, To: time.Now().UTC()}
+	if raw := c.Query("to"); raw != "" {
+		if q.To, err = time.Parse(time.RFC3339, raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+			return
+		}
+	}
+	q.From = q.To.Add(-defaultEventsWindow)
+	if raw := c.Query("from"); raw != "" {
+		if q.From, err = time.Parse(time.RFC3339, raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+			return
+		}
+	}
+	if raw := c.Query("action"); raw != "" {
+		q.Actions = strings.Split(raw, ",")
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	evs, err := h.subs.GetRelationshipEvents(ctx, q, page)
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, evs)
+}
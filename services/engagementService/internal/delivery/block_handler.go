@@ -0,0 +1,109 @@
+package delivery
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type BlockHandler struct {
+	svc *service.BlockService
+}
+
+func NewBlockHandler(svc *service.BlockService) *BlockHandler {
+	return &BlockHandler{svc: svc}
+}
+
+// Block POST api/v1/blocks/:userId
+func (h *BlockHandler) Block(c *gin.Context) {
+	blockerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	blockedID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	created, err := h.svc.Block(ctx, blockerID.(uuid.UUID), blockedID)
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !created {
+		c.JSON(http.StatusOK, gin.H{"message": "already blocked"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "user blocked"})
+}
+
+// Unblock DELETE api/v1/blocks/:userId
+func (h *BlockHandler) Unblock(c *gin.Context) {
+	blockerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	blockedID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := h.svc.Unblock(ctx, blockerID.(uuid.UUID), blockedID); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not blocked"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user unblocked"})
+}
+
+// GetBlocked GET api/v1/blocks?limit=20&cursor=
+func (h *BlockHandler) GetBlocked(c *gin.Context) {
+	blockerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	res, err := h.svc.List(ctx, blockerID.(uuid.UUID), page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,172 @@
+package delivery
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/service"
+	"engagementService/internal/transport/request"
+	"errors"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	maxImportBodyBytes = 4 << 20
+	exportTimeout      = 5 * time.Minute
+)
+
+// FollowImportHandler serves bulk follow imports and social graph exports.
+type FollowImportHandler struct {
+	imports *service.FollowImportService
+	subs    *service.SubscriptionService
+}
+
+func NewFollowImportHandler(imports *service.FollowImportService, subs *service.SubscriptionService) *FollowImportHandler {
+	return &FollowImportHandler{imports: imports, subs: subs}
+}
+
+// Import POST api/v1/sub/import
+// The body is either JSON {"user_ids": [...]} or, with Content-Type text/csv, one user ID per line.
+// Answers 202 with the job; its progress is at GET api/v1/sub/import/:jobId.
+func (h *FollowImportHandler) Import(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBodyBytes)
+
+	var ids []uuid.UUID
+	switch c.ContentType() {
+	case "text/csv":
+		parsed, err := request.ParseUserIDsCSV(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ids = parsed
+	default:
+		req := &request.FollowImportRequest{}
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ids = req.UserIDs
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	job, err := h.imports.Start(ctx, userID.(uuid.UUID), ids)
+	if err != nil {
+		writeImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ImportStatus GET api/v1/sub/import/:jobId
+func (h *FollowImportHandler) ImportStatus(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("jobId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	job, err := h.imports.Get(ctx, jobID, userID.(uuid.UUID))
+	if err != nil {
+		writeImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// Export GET api/v1/sub/export?format=csv|ndjson
+// Streams the caller's followers, then everyone they follow. NDJSON is the default.
+func (h *FollowImportHandler) Export(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "ndjson"))
+	var write func(model.GraphEdge) error
+	var csvWriter *csv.Writer
+	switch format {
+	case "csv":
+		w := csv.NewWriter(c.Writer)
+		csvWriter = w
+		c.Header("Content-Type", "text/csv")
+		write = func(e model.GraphEdge) error {
+			if err := w.Write([]string{e.Relation, e.UserID.String(), e.Since.Format(time.RFC3339)}); err != nil {
+				return err
+			}
+			w.Flush()
+			return w.Error()
+		}
+	case "ndjson":
+		enc := json.NewEncoder(c.Writer)
+		c.Header("Content-Type", "application/x-ndjson")
+		write = func(e model.GraphEdge) error {
+			return enc.Encode(e)
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or ndjson"})
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=social-graph."+format)
+	c.Status(http.StatusOK)
+	if csvWriter != nil {
+		// Sent up front, so an empty graph still gets the header row
+		_ = csvWriter.Write([]string{"relation", "user_id", "since"})
+		csvWriter.Flush()
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), exportTimeout)
+	defer cancel()
+
+	// Headers are already sent, so a failure can only cut the stream short
+	err := h.subs.ExportGraph(ctx, userID.(uuid.UUID), func(e model.GraphEdge) error {
+		if err := write(e); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		logging.GetLogger().WithField("user_id", userID.(uuid.UUID).String()).WithError(err).Warn("Social graph export aborted")
+	}
+}
+
+func writeImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, commonErrors.ErrInvalidArgument):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, commonErrors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, commonErrors.ErrImportInProgress):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
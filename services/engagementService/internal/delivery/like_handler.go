@@ -3,13 +3,14 @@ package delivery
 import (
 	"context"
 	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
 	"engagementService/internal/service"
 	"engagementService/internal/transport/request"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
-	"strconv"
 	"time"
 )
 
@@ -45,7 +46,8 @@ func (h *LikeHandler) GetLikeByID(c *gin.Context) {
 	c.JSON(http.StatusOK, post)
 }
 
-// Like POST api/v1/likes
+// Like POST api/v1/like/:targetType/:targetId
+// The body is optional and may carry a reaction.
 func (h *LikeHandler) Like(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -53,46 +55,86 @@ func (h *LikeHandler) Like(c *gin.Context) {
 		return
 	}
 
-	req := &request.LikeRequest{}
-	err := c.ShouldBindBodyWithJSON(req)
+	target, err := targetParam(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	req := &request.LikeRequest{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindBodyWithJSON(req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	req.UserID = userID.(uuid.UUID)
+	req.TargetType = target.Type
+	req.TargetID = target.ID
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	res, err := h.svc.Create(ctx, req)
+	res, created, err := h.svc.Create(ctx, req)
 	if err != nil {
+		if errors.Is(err, commonErrors.ErrUnknownReaction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if writeRateLimited(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if created {
+		c.JSON(http.StatusCreated, res)
+		return
+	}
 	c.JSON(http.StatusOK, res)
 }
 
-// GetUserLikes GET api/v1/likes/user?limit=10&offset=0
+// GetUserLikes GET api/v1/like/user/:userId/likes?limit=20&cursor=
 func (h *LikeHandler) GetUserLikes(c *gin.Context) {
+	userUUID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
 
-	userId, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	limit := c.Query("limit")
-	offset := c.Query("offset")
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
 
-	limitInt, err := strconv.Atoi(limit)
+	res, err := h.svc.GetByUserID(ctx, userUUID, viewerID(c), page)
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetTargetLikes GET api/v1/like/:targetType/:targetId/likes?limit=20&cursor=
+func (h *LikeHandler) GetTargetLikes(c *gin.Context) {
+	target, err := targetParam(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	offsetInt, err := strconv.Atoi(offset)
+	page, err := parsePageParams(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -101,7 +143,7 @@ func (h *LikeHandler) GetUserLikes(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	res, err := h.svc.GetByUserID(ctx, userId.(uuid.UUID), limitInt, offsetInt)
+	res, err := h.svc.GetByTarget(ctx, target, viewerID(c), page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -110,27 +152,60 @@ func (h *LikeHandler) GetUserLikes(c *gin.Context) {
 	c.JSON(http.StatusOK, res)
 }
 
-// GetPostLikes GET api/v1/likes/post/:post_id?limit=10&offset=0
-func (h *LikeHandler) GetPostLikes(c *gin.Context) {
+// Unlike DELETE api/v1/like/:targetType/:targetId
+func (h *LikeHandler) Unlike(c *gin.Context) {
+	userId, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
 
-	postId := c.Param("post_id")
-	postUUID, err := uuid.Parse(postId)
+	target, err := targetParam(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	limit := c.Query("limit")
-	offset := c.Query("offset")
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	err = h.svc.Unlike(ctx, userId.(uuid.UUID), target)
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "like not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "like deleted"})
+}
 
-	limitInt, err := strconv.Atoi(limit)
+// GetLikeCount GET api/v1/like/:targetType/:targetId/count
+func (h *LikeHandler) GetLikeCount(c *gin.Context) {
+	target, err := targetParam(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	offsetInt, err := strconv.Atoi(offset)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	count, err := h.svc.GetCount(ctx, target)
 	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"target_type": target.Type, "target_id": target.ID, "like_count": count})
+}
+
+// GetLikeCounts POST api/v1/like/:targetType/counts
+func (h *LikeHandler) GetLikeCounts(c *gin.Context) {
+	req := &request.TargetIDsRequest{}
+	if err := c.ShouldBindBodyWithJSON(req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -138,24 +213,101 @@ func (h *LikeHandler) GetPostLikes(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	res, err := h.svc.GetByUserID(ctx, postUUID, limitInt, offsetInt)
+	counts, err := h.svc.GetCounts(ctx, c.Param("targetType"), req.IDs())
 	if err != nil {
+		if errors.Is(err, commonErrors.ErrUnknownTargetType) || errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, res)
+	c.JSON(http.StatusOK, gin.H{"counts": counts})
 }
 
-func (h *LikeHandler) Unlike(c *gin.Context) {
+// GetLikeStatuses POST api/v1/like/:targetType/status
+func (h *LikeHandler) GetLikeStatuses(c *gin.Context) {
+	userId, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	req := &request.TargetIDsRequest{}
+	if err := c.ShouldBindBodyWithJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	statuses, err := h.svc.GetStatuses(ctx, userId.(uuid.UUID), c.Param("targetType"), req.IDs())
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrUnknownTargetType) || errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"statuses": statuses})
+}
+
+// ChangeReaction PUT api/v1/like/:targetType/:targetId/reaction
+// Sets the user's reaction on a target, liking it first if needed.
+func (h *LikeHandler) ChangeReaction(c *gin.Context) {
 	userId, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
-	likeId := c.Param("like_id")
-	likeUUID, err := uuid.Parse(likeId)
+	target, err := targetParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body := &request.ReactionRequest{}
+	if err := c.ShouldBindBodyWithJSON(body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	res, created, err := h.svc.Create(ctx, &request.LikeRequest{
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		UserID:     userId.(uuid.UUID),
+		Reaction:   body.Reaction,
+	})
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrUnknownReaction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if writeRateLimited(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetReactions GET api/v1/like/:targetType/:targetId/reactions
+func (h *LikeHandler) GetReactions(c *gin.Context) {
+	target, err := targetParam(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -164,11 +316,26 @@ func (h *LikeHandler) Unlike(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	err = h.svc.Delete(ctx, likeUUID, userId.(uuid.UUID))
+	counts, err := h.svc.GetReactionCounts(ctx, target)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "like deleted"})
+	c.JSON(http.StatusOK, gin.H{"target_type": target.Type, "target_id": target.ID, "reactions": counts})
+}
+
+// targetParam reads the :targetType and :targetId path parameters.
+func targetParam(c *gin.Context) (model.Target, error) {
+	targetType := c.Param("targetType")
+	if !model.IsTargetType(targetType) {
+		return model.Target{}, fmt.Errorf("%w: %s", commonErrors.ErrUnknownTargetType, targetType)
+	}
+
+	id, err := uuid.Parse(c.Param("targetId"))
+	if err != nil {
+		return model.Target{}, errors.New("invalid target id")
+	}
+
+	return model.Target{Type: targetType, ID: id}, nil
 }
@@ -0,0 +1,388 @@
+package delivery
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/pagination"
+	"engagementService/internal/service"
+	"engagementService/internal/transport/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type ListHandler struct {
+	svc *service.ListService
+}
+
+func NewListHandler(svc *service.ListService) *ListHandler {
+	return &ListHandler{svc: svc}
+}
+
+// CreateList POST api/v1/lists
+func (h *ListHandler) CreateList(c *gin.Context) {
+	ownerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	req := &request.CreateListRequest{}
+	if err := c.ShouldBindBodyWithJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	list, err := h.svc.Create(ctx, ownerID.(uuid.UUID), req.Name, req.Description, req.IsPrivate)
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// GetMyLists GET api/v1/lists?limit=20&cursor=
+func (h *ListHandler) GetMyLists(c *gin.Context) {
+	ownerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	lists, err := h.svc.ListOwned(ctx, ownerID.(uuid.UUID), ownerID.(uuid.UUID), page)
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+// GetUserLists GET api/v1/lists/user/:userId?limit=20&cursor=
+func (h *ListHandler) GetUserLists(c *gin.Context) {
+	ownerID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	lists, err := h.svc.ListOwned(ctx, ownerID, viewerID(c), page)
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+// GetFollowedLists GET api/v1/lists/followed?limit=20&cursor=
+func (h *ListHandler) GetFollowedLists(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	lists, err := h.svc.ListFollowed(ctx, userID.(uuid.UUID), page)
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+// GetCloseFriends GET api/v1/lists/close-friends
+func (h *ListHandler) GetCloseFriends(c *gin.Context) {
+	ownerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	list, err := h.svc.GetCloseFriends(ctx, ownerID.(uuid.UUID))
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// GetList GET api/v1/lists/:listId
+func (h *ListHandler) GetList(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("listId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	list, err := h.svc.Get(ctx, listID, viewerID(c))
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// UpdateList PATCH api/v1/lists/:listId
+func (h *ListHandler) UpdateList(c *gin.Context) {
+	ownerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	listID, err := uuid.Parse(c.Param("listId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list id"})
+		return
+	}
+
+	req := &request.UpdateListRequest{}
+	if err := c.ShouldBindBodyWithJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	list, err := h.svc.Update(ctx, listID, ownerID.(uuid.UUID), service.ListUpdate{
+		Name:        req.Name,
+		Description: req.Description,
+		IsPrivate:   req.IsPrivate,
+	})
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// DeleteList DELETE api/v1/lists/:listId
+func (h *ListHandler) DeleteList(c *gin.Context) {
+	ownerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	listID, err := uuid.Parse(c.Param("listId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := h.svc.Delete(ctx, listID, ownerID.(uuid.UUID)); err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "list deleted"})
+}
+
+// GetMembers GET api/v1/lists/:listId/members?limit=20&cursor=
+func (h *ListHandler) GetMembers(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("listId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list id"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	members, err := h.svc.GetMembers(ctx, listID, viewerID(c), page)
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// AddMember PUT api/v1/lists/:listId/members/:userId
+func (h *ListHandler) AddMember(c *gin.Context) {
+	ownerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	listID, memberID, ok := parseListMember(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	added, err := h.svc.AddMember(ctx, listID, ownerID.(uuid.UUID), memberID)
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	if !added {
+		c.JSON(http.StatusOK, gin.H{"message": "already a member"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "member added"})
+}
+
+// RemoveMember DELETE api/v1/lists/:listId/members/:userId
+func (h *ListHandler) RemoveMember(c *gin.Context) {
+	ownerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	listID, memberID, ok := parseListMember(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := h.svc.RemoveMember(ctx, listID, ownerID.(uuid.UUID), memberID); err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "member removed"})
+}
+
+// FollowList POST api/v1/lists/:listId/follow
+func (h *ListHandler) FollowList(c *gin.Context) {
+	followerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	listID, err := uuid.Parse(c.Param("listId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	followed, err := h.svc.Follow(ctx, listID, followerID.(uuid.UUID))
+	if err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	if !followed {
+		c.JSON(http.StatusOK, gin.H{"message": "already following"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "list followed"})
+}
+
+// UnfollowList DELETE api/v1/lists/:listId/follow
+func (h *ListHandler) UnfollowList(c *gin.Context) {
+	followerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	listID, err := uuid.Parse(c.Param("listId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := h.svc.Unfollow(ctx, listID, followerID.(uuid.UUID)); err != nil {
+		writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "list unfollowed"})
+}
+
+func parseListMember(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	listID, err := uuid.Parse(c.Param("listId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return listID, memberID, true
+}
+
+func writeListError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, commonErrors.ErrInvalidArgument), errors.Is(err, pagination.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, commonErrors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, commonErrors.ErrBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/service"
+	"engagementService/internal/transport/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type MuteHandler struct {
+	svc *service.MuteService
+}
+
+func NewMuteHandler(svc *service.MuteService) *MuteHandler {
+	return &MuteHandler{svc: svc}
+}
+
+// Mute POST api/v1/mutes/:userId
+// The body is optional and may set duration_seconds for a timed mute.
+func (h *MuteHandler) Mute(c *gin.Context) {
+	muterID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	mutedID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	req := &request.MuteRequest{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindBodyWithJSON(req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	mute, err := h.svc.Mute(ctx, muterID.(uuid.UUID), mutedID, time.Duration(req.DurationSeconds)*time.Second)
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mute)
+}
+
+// Unmute DELETE api/v1/mutes/:userId
+func (h *MuteHandler) Unmute(c *gin.Context) {
+	muterID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	mutedID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := h.svc.Unmute(ctx, muterID.(uuid.UUID), mutedID); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not muted"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user unmuted"})
+}
+
+// GetMuted GET api/v1/mutes?limit=20&cursor=
+func (h *MuteHandler) GetMuted(c *gin.Context) {
+	muterID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	res, err := h.svc.List(ctx, muterID.(uuid.UUID), page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetMutedIDs GET api/v1/mutes/ids
+func (h *MuteHandler) GetMutedIDs(c *gin.Context) {
+	muterID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	ids, err := h.svc.GetMutedIDs(ctx, muterID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"muted_ids": ids})
+}
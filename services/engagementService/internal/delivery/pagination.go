@@ -0,0 +1,39 @@
+package delivery
+
+import (
+	"engagementService/internal/pagination"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+// parsePageParams reads ?limit=&cursor= from the query string.
+// ?offset= is still honoured for older clients, but cursor wins when both are present.
+func parsePageParams(c *gin.Context) (pagination.Params, error) {
+	p := pagination.Params{Limit: pagination.DefaultLimit}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return p, errors.New("invalid limit")
+		}
+		p.Limit = limit
+	}
+
+	if token := c.Query("cursor"); token != "" {
+		cursor, err := pagination.Decode(token)
+		if err != nil {
+			return p, err
+		}
+		p.After = cursor
+	} else if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil {
+			return p, errors.New("invalid offset")
+		}
+		p.Offset = offset
+		c.Header("Deprecation", "true")
+	}
+
+	return p, p.Validate()
+}
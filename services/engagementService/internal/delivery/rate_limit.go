@@ -0,0 +1,22 @@
+package delivery
+
+import (
+	commonErrors "engagementService/internal/errors"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// writeRateLimited answers 429 with Retry-After in whole seconds if err is a rate limit refusal,
+// and reports whether it did.
+func writeRateLimited(c *gin.Context, err error) bool {
+	var rl *commonErrors.RateLimitError
+	if !errors.As(err, &rl) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rl.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	return true
+}
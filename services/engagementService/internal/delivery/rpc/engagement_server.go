@@ -0,0 +1,242 @@
+package rpc
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"engagementService/internal/service"
+	"errors"
+	"fmt"
+	engagementpb "github.com/Sayan80bayev/go-project/pkg/proto/engagement"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EngagementServer serves likes and subscriptions to other services over gRPC.
+// Unlike the REST routes it is not behind JWT auth; it is meant for the internal network only.
+type EngagementServer struct {
+	engagementpb.UnimplementedEngagementServiceServer
+
+	likes *service.LikeService
+	subs  *service.SubscriptionService
+	mutes *service.MuteService
+	lists *service.ListService
+}
+
+// NewEngagementServer creates a new EngagementServer.
+func NewEngagementServer(likes *service.LikeService, subs *service.SubscriptionService, mutes *service.MuteService, lists *service.ListService) *EngagementServer {
+	return &EngagementServer{likes: likes, subs: subs, mutes: mutes, lists: lists}
+}
+
+func (s *EngagementServer) GetLikeCount(ctx context.Context, req *engagementpb.GetLikeCountRequest) (*engagementpb.GetLikeCountResponse, error) {
+	id, err := parseID("target_id", req.GetTargetId())
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.likes.GetCount(ctx, model.Target{Type: targetType(req.GetTargetType()), ID: id})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &engagementpb.GetLikeCountResponse{LikeCount: count}, nil
+}
+
+func (s *EngagementServer) GetLikeCounts(ctx context.Context, req *engagementpb.GetLikeCountsRequest) (*engagementpb.GetLikeCountsResponse, error) {
+	ids, err := parseIDs("target_ids", req.GetTargetIds())
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.likes.GetCounts(ctx, targetType(req.GetTargetType()), ids)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &engagementpb.GetLikeCountsResponse{Counts: make(map[string]int64, len(counts))}
+	for id, count := range counts {
+		res.Counts[id.String()] = count
+	}
+	return res, nil
+}
+
+func (s *EngagementServer) GetLikeStatuses(ctx context.Context, req *engagementpb.GetLikeStatusesRequest) (*engagementpb.GetLikeStatusesResponse, error) {
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	ids, err := parseIDs("target_ids", req.GetTargetIds())
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.likes.GetStatuses(ctx, userID, targetType(req.GetTargetType()), ids)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &engagementpb.GetLikeStatusesResponse{Statuses: make(map[string]*engagementpb.LikeStatus, len(statuses))}
+	for id, st := range statuses {
+		out := &engagementpb.LikeStatus{Liked: st.Liked}
+		if st.LikeID != nil {
+			likeID := st.LikeID.String()
+			out.LikeId = &likeID
+		}
+		res.Statuses[id.String()] = out
+	}
+	return res, nil
+}
+
+func (s *EngagementServer) GetFollowCounts(ctx context.Context, req *engagementpb.GetFollowCountsRequest) (*engagementpb.GetFollowCountsResponse, error) {
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.subs.GetFollowStats(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &engagementpb.GetFollowCountsResponse{Followers: stats.Followers, Following: stats.Following}, nil
+}
+
+func (s *EngagementServer) IsFollowing(ctx context.Context, req *engagementpb.IsFollowingRequest) (*engagementpb.IsFollowingResponse, error) {
+	followerID, err := parseID("follower_id", req.GetFollowerId())
+	if err != nil {
+		return nil, err
+	}
+	followeeID, err := parseID("followee_id", req.GetFolloweeId())
+	if err != nil {
+		return nil, err
+	}
+
+	following, err := s.subs.IsFollowing(ctx, followerID, followeeID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &engagementpb.IsFollowingResponse{Following: following}, nil
+}
+
+func (s *EngagementServer) GetFollowerIds(ctx context.Context, req *engagementpb.GetFollowerIdsRequest) (*engagementpb.GetFollowerIdsResponse, error) {
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	p := pagination.Params{Limit: int(req.GetLimit())}
+	if p.Limit == 0 {
+		p.Limit = pagination.DefaultLimit
+	}
+	if req.GetCursor() != "" {
+		after, err := pagination.Decode(req.GetCursor())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		p.After = after
+	}
+	if err := p.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Internal callers (e.g. feed fan-out) need every follower, so no viewer filter applies
+	page, err := s.subs.GetFollowers(ctx, userID, uuid.Nil, p)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &engagementpb.GetFollowerIdsResponse{
+		FollowerIds: make([]string, 0, len(page.Items)),
+		NextCursor:  page.NextCursor,
+		HasMore:     page.HasMore,
+	}
+	for _, sub := range page.Items {
+		res.FollowerIds = append(res.FollowerIds, sub.FollowerID.String())
+	}
+	return res, nil
+}
+
+func (s *EngagementServer) GetMutedIds(ctx context.Context, req *engagementpb.GetMutedIdsRequest) (*engagementpb.GetMutedIdsResponse, error) {
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.mutes.GetMutedIDs(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &engagementpb.GetMutedIdsResponse{MutedIds: make([]string, 0, len(ids))}
+	for _, id := range ids {
+		res.MutedIds = append(res.MutedIds, id.String())
+	}
+	return res, nil
+}
+
+func (s *EngagementServer) IsCloseFriend(ctx context.Context, req *engagementpb.IsCloseFriendRequest) (*engagementpb.IsCloseFriendResponse, error) {
+	ownerID, err := parseID("owner_id", req.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.lists.IsCloseFriend(ctx, ownerID, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &engagementpb.IsCloseFriendResponse{CloseFriend: ok}, nil
+}
+
+// targetType defaults to posts, which is what most callers ask about.
+func targetType(t string) string {
+	if t == "" {
+		return model.TargetPost
+	}
+	return t
+}
+
+func parseID(field, raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", field, err)
+	}
+	return id, nil
+}
+
+func parseIDs(field string, raw []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	for i, r := range raw {
+		id, err := parseID(fmt.Sprintf("%s[%d]", field, i), r)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// toStatus maps service errors to gRPC status codes.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, commonErrors.ErrInvalidArgument),
+		errors.Is(err, commonErrors.ErrUnknownTargetType),
+		errors.Is(err, pagination.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, commonErrors.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	commonErrors "engagementService/internal/errors"
 	"engagementService/internal/service"
+	"engagementService/internal/transport/request"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
-	"strconv"
 	"time"
 )
 
@@ -38,16 +38,32 @@ func (h *SubscriptionHandler) Follow(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	err = h.svc.Follow(ctx, followerID.(uuid.UUID), followeeID)
+	sub, err := h.svc.Follow(ctx, followerID.(uuid.UUID), followeeID)
 	if err != nil {
 		if errors.Is(err, commonErrors.ErrAlreadyFollowing) {
-			c.JSON(http.StatusConflict, gin.H{"error": "already following"})
+			// Following is idempotent: repeating the request is not an error
+			c.JSON(http.StatusOK, gin.H{"message": "already following"})
+			return
+		}
+		if errors.Is(err, commonErrors.ErrFollowRequestPending) {
+			c.JSON(http.StatusOK, gin.H{"message": "follow request already pending"})
+			return
+		}
+		if errors.Is(err, commonErrors.ErrBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if writeRateLimited(c, err) {
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if !sub.Approved {
+		c.JSON(http.StatusAccepted, gin.H{"message": "follow request sent"})
+		return
+	}
 	c.JSON(http.StatusCreated, gin.H{"message": "followed successfully"})
 }
 
@@ -70,6 +86,10 @@ func (h *SubscriptionHandler) Unfollow(c *gin.Context) {
 	defer cancel()
 
 	if err := h.svc.Unfollow(ctx, followerID.(uuid.UUID), followeeID); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFollowing) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not following"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -77,7 +97,7 @@ func (h *SubscriptionHandler) Unfollow(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "unfollowed successfully"})
 }
 
-// GetFollowers: GET /subscriptions/:userId/followers?limit=10&offset=0
+// GetFollowers: GET /subscriptions/:userId/followers?limit=20&cursor=
 func (h *SubscriptionHandler) GetFollowers(c *gin.Context) {
 	userIdStr := c.Param("userId")
 	userID, err := uuid.Parse(userIdStr)
@@ -86,14 +106,21 @@ func (h *SubscriptionHandler) GetFollowers(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
-	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	subs, err := h.svc.GetFollowers(ctx, userID, limit, offset)
+	subs, err := h.svc.GetFollowers(ctx, userID, viewerID(c), page)
 	if err != nil {
+		if errors.Is(err, commonErrors.ErrBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -101,7 +128,7 @@ func (h *SubscriptionHandler) GetFollowers(c *gin.Context) {
 	c.JSON(http.StatusOK, subs)
 }
 
-// GetFollowing: GET /subscriptions/:userId/following?limit=10&offset=0
+// GetFollowing: GET /subscriptions/:userId/following?limit=20&cursor=
 func (h *SubscriptionHandler) GetFollowing(c *gin.Context) {
 	userIdStr := c.Param("userId")
 	userID, err := uuid.Parse(userIdStr)
@@ -110,14 +137,21 @@ func (h *SubscriptionHandler) GetFollowing(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
-	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	subs, err := h.svc.GetFollowing(ctx, userID, limit, offset)
+	subs, err := h.svc.GetFollowing(ctx, userID, viewerID(c), page)
 	if err != nil {
+		if errors.Is(err, commonErrors.ErrBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -125,6 +159,106 @@ func (h *SubscriptionHandler) GetFollowing(c *gin.Context) {
 	c.JSON(http.StatusOK, subs)
 }
 
+// GetMutualFollowers: GET /subscriptions/:userId/mutual/:otherUserId?limit=20&cursor=
+func (h *SubscriptionHandler) GetMutualFollowers(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	otherID, err := uuid.Parse(c.Param("otherUserId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid other user id"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	subs, err := h.svc.GetMutualFollowers(ctx, userID, otherID, viewerID(c), page)
+	if err != nil {
+		h.writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+// GetFollowersYouKnow: GET /subscriptions/:userId/followers/known?limit=20&cursor=
+func (h *SubscriptionHandler) GetFollowersYouKnow(c *gin.Context) {
+	viewer, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	subs, err := h.svc.GetFollowersYouKnow(ctx, userID, viewer.(uuid.UUID), page)
+	if err != nil {
+		h.writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+// GetFriends: GET /subscriptions/:userId/friends?limit=20&cursor=
+func (h *SubscriptionHandler) GetFriends(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	subs, err := h.svc.GetFriends(ctx, userID, viewerID(c), page)
+	if err != nil {
+		h.writeListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+func (h *SubscriptionHandler) writeListError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, commonErrors.ErrBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, commonErrors.ErrInvalidArgument):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 // IsFollowing: GET /subscriptions/is-following/:followeeId
 func (h *SubscriptionHandler) IsFollowing(c *gin.Context) {
 	followerID, exists := c.Get("user_id")
@@ -164,19 +298,177 @@ func (h *SubscriptionHandler) Count(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	followers, err := h.svc.CountFollowers(ctx, userID)
+	stats, err := h.svc.GetFollowStats(ctx, userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	following, err := h.svc.CountFollowing(ctx, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"followers": stats.Followers,
+		"following": stats.Following,
+	})
+}
+
+// BatchStats: POST /subscriptions/stats
+func (h *SubscriptionHandler) BatchStats(c *gin.Context) {
+	req := &request.UserIDsRequest{}
+	if err := c.ShouldBindBodyWithJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	stats, err := h.svc.GetFollowStatsBatch(ctx, req.UserIDs)
 	if err != nil {
+		if errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"followers": followers,
-		"following": following,
-	})
+	c.JSON(http.StatusOK, gin.H{"stats": stats})
+}
+
+// GetPendingRequests: GET /subscriptions/requests?limit=20&cursor=
+func (h *SubscriptionHandler) GetPendingRequests(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, err := parsePageParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	subs, err := h.svc.GetPendingRequests(ctx, userID.(uuid.UUID), page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+// ApproveRequest: POST /subscriptions/requests/:followerId/approve
+func (h *SubscriptionHandler) ApproveRequest(c *gin.Context) {
+	h.answerRequest(c, h.svc.ApproveRequest, "follow request approved")
+}
+
+// RejectRequest: POST /subscriptions/requests/:followerId/reject
+func (h *SubscriptionHandler) RejectRequest(c *gin.Context) {
+	h.answerRequest(c, h.svc.RejectRequest, "follow request rejected")
+}
+
+func (h *SubscriptionHandler) answerRequest(c *gin.Context, answer func(ctx context.Context, followeeID, followerID uuid.UUID) error, message string) {
+	followeeID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	followerID, err := uuid.Parse(c.Param("followerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid follower id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := answer(ctx, followeeID.(uuid.UUID), followerID); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "follow request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// CancelRequest: DELETE /subscriptions/:followeeId/request
+func (h *SubscriptionHandler) CancelRequest(c *gin.Context) {
+	followerID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	followeeID, err := uuid.Parse(c.Param("followeeId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid followee id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := h.svc.CancelRequest(ctx, followerID.(uuid.UUID), followeeID); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "follow request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "follow request cancelled"})
+}
+
+// GetPrivacy: GET /subscriptions/settings/privacy
+func (h *SubscriptionHandler) GetPrivacy(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	settings, err := h.svc.GetPrivacy(ctx, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdatePrivacy: PUT /subscriptions/settings/privacy
+func (h *SubscriptionHandler) UpdatePrivacy(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	req := &request.PrivacyRequest{}
+	if err := c.ShouldBindBodyWithJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	settings, err := h.svc.SetPrivacy(ctx, userID.(uuid.UUID), *req.IsPrivate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
 }
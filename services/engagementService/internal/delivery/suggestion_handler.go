@@ -0,0 +1,86 @@
+package delivery
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/pagination"
+	"engagementService/internal/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type SuggestionHandler struct {
+	svc *service.SuggestionService
+}
+
+func NewSuggestionHandler(svc *service.SuggestionService) *SuggestionHandler {
+	return &SuggestionHandler{svc: svc}
+}
+
+// GetSuggestions GET api/v1/sub/suggestions?limit=20
+func (h *SuggestionHandler) GetSuggestions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	limit := pagination.DefaultLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = n
+	}
+
+	// A cache miss computes the list inline, which takes longer than a plain read
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	suggestions, err := h.svc.Get(ctx, userID.(uuid.UUID), limit)
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": suggestions})
+}
+
+// DismissSuggestion POST api/v1/sub/suggestions/:userId/dismiss
+func (h *SuggestionHandler) DismissSuggestion(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	dismissedID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	if err := h.svc.Dismiss(ctx, userID.(uuid.UUID), dismissedID); err != nil {
+		if errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "suggestion dismissed"})
+}
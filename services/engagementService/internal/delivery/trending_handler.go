@@ -0,0 +1,51 @@
+package delivery
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/pagination"
+	"engagementService/internal/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type TrendingHandler struct {
+	svc *service.TrendingService
+}
+
+func NewTrendingHandler(svc *service.TrendingService) *TrendingHandler {
+	return &TrendingHandler{svc: svc}
+}
+
+// GetTrending GET api/v1/trending?window=24h&limit=20
+func (h *TrendingHandler) GetTrending(c *gin.Context) {
+	window := c.DefaultQuery("window", service.DefaultTrendingWindow)
+
+	limit := pagination.DefaultLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	posts, err := h.svc.GetTrending(ctx, window, limit)
+	if err != nil {
+		if errors.Is(err, commonErrors.ErrInvalidArgument) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"window": window, "items": posts})
+}
@@ -0,0 +1,16 @@
+package delivery
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// viewerID returns the authenticated caller, or uuid.Nil when there is none.
+func viewerID(c *gin.Context) uuid.UUID {
+	if v, exists := c.Get("user_id"); exists {
+		if id, ok := v.(uuid.UUID); ok {
+			return id
+		}
+	}
+	return uuid.Nil
+}
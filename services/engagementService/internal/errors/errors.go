@@ -1,6 +1,9 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // Common errors
 var (
@@ -9,5 +12,24 @@ var (
 	ErrDuplicateSubscription = errors.New("subscription already exists")
 	ErrAlreadyFollowing      = errors.New("already following")
 	ErrNotFollowing          = errors.New("not following")
-	ErrDuplicateLike         = errors.New("like already exists for user and post")
-)
\ No newline at end of file
+	ErrFollowRequestPending  = errors.New("follow request already pending")
+	ErrBlocked               = errors.New("user is blocked")
+	ErrDuplicateLike         = errors.New("like already exists for user and target")
+	ErrUnknownReaction       = errors.New("unknown reaction type")
+	ErrUnknownTargetType     = errors.New("unknown target type")
+	ErrImportInProgress      = errors.New("an import is already in progress")
+	ErrRateLimited           = errors.New("rate limit exceeded")
+)
+
+// RateLimitError is returned when an action is refused by a rate limit. It matches ErrRateLimited.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return ErrRateLimited.Error()
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
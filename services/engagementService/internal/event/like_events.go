@@ -1,13 +0,0 @@
-package events
-
-import (
-	"github.com/google/uuid"
-	"time"
-)
-
-type LikeEvent struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	PostID    uuid.UUID `json:"post_id"`
-	CreatedAt time.Time `json:"created_at"`
-}
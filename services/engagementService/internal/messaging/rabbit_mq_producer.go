@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/messaging"
 	"github.com/sirupsen/logrus"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -13,10 +14,12 @@ type RabbitProducer struct {
 	conn     *amqp.Connection
 	channel  *amqp.Channel
 	exchange string
+	source   string
 	logger   *logrus.Logger
 }
 
-func NewRabbitProducer(amqpURL, exchange string, logger *logrus.Logger) (*RabbitProducer, error) {
+// NewRabbitProducer connects to RabbitMQ and declares the exchange. source names the service in the envelope of its events.
+func NewRabbitProducer(amqpURL, exchange, source string, logger *logrus.Logger) (*RabbitProducer, error) {
 	conn, err := amqp.Dial(amqpURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
@@ -48,17 +51,35 @@ func NewRabbitProducer(amqpURL, exchange string, logger *logrus.Logger) (*Rabbit
 		conn:     conn,
 		channel:  ch,
 		exchange: exchange,
+		source:   source,
 		logger:   logger,
 	}, nil
 }
 
-func (p *RabbitProducer) Produce(ctx context.Context, eventType string, data interface{}) error {
-	body, err := json.Marshal(data)
+// Produce publishes the event envelope with its type as the routing key. RabbitMQ has no partitions,
+// so the partition key travels in the partition_key header, e.g. for a consistent-hash exchange.
+func (p *RabbitProducer) Produce(ctx context.Context, eventType string, data interface{}, opts ...messaging.ProduceOption) error {
+	o := messaging.NewProduceOptions(opts...)
+	event, err := messaging.NewEvent(ctx, eventType, p.source, data, o)
+	if err != nil {
+		p.logger.Errorf("failed to build event: %v", err)
+		return err
+	}
+
+	body, err := json.Marshal(event)
 	if err != nil {
 		p.logger.Errorf("failed to marshal message: %v", err)
 		return err
 	}
 
+	headers := amqp.Table{}
+	for name, value := range o.Headers {
+		headers[name] = value
+	}
+	if o.Key != "" {
+		headers["partition_key"] = o.Key
+	}
+
 	err = p.channel.PublishWithContext(
 		ctx,
 		p.exchange,
@@ -66,9 +87,15 @@ func (p *RabbitProducer) Produce(ctx context.Context, eventType string, data int
 		false,
 		false,
 		amqp.Publishing{
-			ContentType:  "application/json",
-			DeliveryMode: amqp.Persistent,
-			Body:         body,
+			ContentType:   "application/json",
+			DeliveryMode:  amqp.Persistent,
+			MessageId:     event.ID,
+			CorrelationId: event.CorrelationID,
+			Timestamp:     event.OccurredAt,
+			Type:          eventType,
+			AppId:         p.source,
+			Headers:       headers,
+			Body:          body,
 		},
 	)
 	if err != nil {
@@ -76,7 +103,7 @@ func (p *RabbitProducer) Produce(ctx context.Context, eventType string, data int
 		return err
 	}
 
-	p.logger.Infof("[Producer] event=%s message=%s", eventType, string(body))
+	p.logger.Infof("[Producer] event=%s key=%s message=%s", eventType, o.Key, string(body))
 	return nil
 }
 
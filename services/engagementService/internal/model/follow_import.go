@@ -0,0 +1,53 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Follow import job statuses
+const (
+	ImportPending = "pending"
+	ImportRunning = "running"
+	ImportDone    = "done"
+	ImportFailed  = "failed"
+)
+
+// FollowImportJob follows a list of accounts on behalf of UserID in the background.
+// Processed counts the IDs handled so far, so an interrupted job resumes where it stopped.
+type FollowImportJob struct {
+	ID         uuid.UUID   `json:"id"`
+	UserID     uuid.UUID   `json:"user_id"`
+	Status     string      `json:"status"`
+	UserIDs    []uuid.UUID `json:"-"`
+	Total      int         `json:"total"`
+	Processed  int         `json:"processed"`
+	Followed   int         `json:"followed"`
+	Requested  int         `json:"requested"` // private accounts, which got follow requests instead
+	Skipped    int         `json:"skipped"`   // already followed or requested, blocked, or the user themselves
+	LastError  *string     `json:"last_error,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+	ResumeAt   *time.Time  `json:"resume_at,omitempty"` // set while a pending job waits for the import rate limit
+}
+
+// BulkFollowResult tells which accounts of a bulk follow were followed and which got follow requests.
+// Accounts in neither were skipped.
+type BulkFollowResult struct {
+	Followed  []uuid.UUID
+	Requested []uuid.UUID
+}
+
+// Relations of an exported social graph entry to the exporting user
+const (
+	GraphFollower  = "follower"
+	GraphFollowing = "following"
+)
+
+// GraphEdge is one line of a social graph export.
+type GraphEdge struct {
+	Relation string    `json:"relation"`
+	UserID   uuid.UUID `json:"user_id"`
+	Since    time.Time `json:"since"`
+}
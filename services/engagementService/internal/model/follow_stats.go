@@ -0,0 +1,10 @@
+package model
+
+import "github.com/google/uuid"
+
+// FollowStats holds a user's follower and following counts
+type FollowStats struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Followers int64     `json:"followers"`
+	Following int64     `json:"following"`
+}
@@ -6,9 +6,22 @@ import (
 )
 
 type Like struct {
-	ID        uuid.UUID  `json:"id"`
-	UserID    uuid.UUID  `json:"user_id"`
-	PostID    uuid.UUID  `json:"post_id"`
-	CreatedAt time.Time  `json:"created_at"`
-	DeletedAt *time.Time `json:"deleted_at"`
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	TargetType string     `json:"target_type"`
+	TargetID   uuid.UUID  `json:"target_id"`
+	Reaction   string     `json:"reaction"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+// Target returns the entity this like points at
+func (l *Like) Target() Target {
+	return Target{Type: l.TargetType, ID: l.TargetID}
+}
+
+// LikeStatus tells whether a viewer has liked a target
+type LikeStatus struct {
+	Liked  bool       `json:"liked"`
+	LikeID *uuid.UUID `json:"like_id,omitempty"`
 }
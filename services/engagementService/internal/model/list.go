@@ -0,0 +1,41 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// List kinds. Close friends is a system list: every user has one, it is always private,
+// and it can't be renamed or deleted, so other services can rely on it for post visibility.
+const (
+	ListKindCustom       = "custom"
+	ListKindCloseFriends = "close_friends"
+)
+
+// CloseFriendsListName is the name the close friends list is created with
+const CloseFriendsListName = "Close friends"
+
+// UserList is a named list of users curated by its owner
+type UserList struct {
+	ID          uuid.UUID  `json:"id"`
+	OwnerID     uuid.UUID  `json:"owner_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	IsPrivate   bool       `json:"is_private"`
+	Kind        string     `json:"kind"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	FollowedAt  *time.Time `json:"followed_at,omitempty"` // set when listing the lists a user follows
+}
+
+// IsSystem reports whether the list is managed by the service rather than the user
+func (l *UserList) IsSystem() bool {
+	return l.Kind != ListKindCustom
+}
+
+// ListMember is a user added to a list
+type ListMember struct {
+	ListID   uuid.UUID `json:"list_id"`
+	MemberID uuid.UUID `json:"member_id"`
+	AddedAt  time.Time `json:"added_at"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mute hides MutedID's activity from MuterID without unfollowing.
+// Only the muter knows about it.
+type Mute struct {
+	MuterID   uuid.UUID  `json:"muter_id"`
+	MutedID   uuid.UUID  `json:"muted_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // nil until unmuted
+}
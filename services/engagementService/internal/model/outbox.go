@@ -0,0 +1,22 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is an event stored in the same transaction as the write that produced it
+type OutboxMessage struct {
+	ID            uuid.UUID       `json:"id"`
+	EventType     string          `json:"event_type"`
+	PartitionKey  string          `json:"partition_key,omitempty"`  // empty for events with no ordering entity
+	CorrelationID string          `json:"correlation_id,omitempty"` // request that produced the event, if known
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	LastError     *string         `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty"` // nil until the relay publishes it
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PrivacySettings holds a user's privacy preferences.
+// Users without a stored row are public.
+type PrivacySettings struct {
+	UserID    uuid.UUID `json:"user_id"`
+	IsPrivate bool      `json:"is_private"` // follows need the user's approval
+	UpdatedAt time.Time `json:"updated_at"`
+}
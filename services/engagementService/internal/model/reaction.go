@@ -0,0 +1,16 @@
+package model
+
+// Built-in reaction types. More can be enabled through config.
+const (
+	ReactionLike  = "like"
+	ReactionLove  = "love"
+	ReactionLaugh = "laugh"
+	ReactionSad   = "sad"
+	ReactionAngry = "angry"
+
+	// DefaultReaction is used when a client doesn't send one, and for likes created before reactions existed
+	DefaultReaction = ReactionLike
+)
+
+// BuiltinReactions lists the reaction types that are always accepted
+var BuiltinReactions = []string{ReactionLike, ReactionLove, ReactionLaugh, ReactionSad, ReactionAngry}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Relationship actions, recorded from the actor's point of view
+const (
+	RelationshipFollow        = "follow"
+	RelationshipFollowRequest = "follow_request"
+	RelationshipUnfollow      = "unfollow"
+	RelationshipApprove       = "approve"
+	RelationshipReject        = "reject"
+	RelationshipCancelRequest = "cancel_request"
+	RelationshipBlock         = "block"
+	RelationshipUnblock       = "unblock"
+	RelationshipMute          = "mute"
+	RelationshipUnmute        = "unmute"
+)
+
+var relationshipActions = map[string]bool{
+	RelationshipFollow:        true,
+	RelationshipFollowRequest: true,
+	RelationshipUnfollow:      true,
+	RelationshipApprove:       true,
+	RelationshipReject:        true,
+	RelationshipCancelRequest: true,
+	RelationshipBlock:         true,
+	RelationshipUnblock:       true,
+	RelationshipMute:          true,
+	RelationshipUnmute:        true,
+}
+
+// IsRelationshipAction reports whether a is one of the recorded actions
+func IsRelationshipAction(a string) bool {
+	return relationshipActions[a]
+}
+
+// RelationshipEvent is one entry of the append-only relationship history:
+// ActorID did Action to TargetID at CreatedAt
+type RelationshipEvent struct {
+	ID        uuid.UUID `json:"id"`
+	ActorID   uuid.UUID `json:"actor_id"`
+	TargetID  uuid.UUID `json:"target_id"`
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RelationshipEventQuery selects the history of one user, as actor or target, within [From, To)
+type RelationshipEventQuery struct {
+	UserID  uuid.UUID
+	From    time.Time
+	To      time.Time
+	Actions []string // empty means all
+}
@@ -12,6 +12,7 @@ type Subscription struct {
 	FollowerID uuid.UUID  `json:"follower_id"` // who subscribes
 	FolloweeID uuid.UUID  `json:"followee_id"` // whom they subscribe to
 	Approved   bool       `json:"approved"`       // for example, for private accounts
+	Muted      bool       `json:"muted,omitempty"` // the follower muted the followee; only shown to the follower
 	CreatedAt  time.Time  `json:"created_at"`
 	DeletedAt  *time.Time `json:"deleted_at,omitempty"` // soft delete
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package model
+
+import "github.com/google/uuid"
+
+// Suggestion sources, reported as the reason of a suggestion
+const (
+	SuggestionFriendsOfFriends = "friends_of_friends"
+	SuggestionPopular          = "popular"
+	SuggestionRecentlyActive   = "recently_active"
+)
+
+// Suggestion is an account recommended for a user to follow
+type Suggestion struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Score       float64   `json:"score"`
+	Reason      string    `json:"reason"`                 // the source that contributed most to Score
+	MutualCount int64     `json:"mutual_count,omitempty"` // followees of the user who follow this account
+}
+
+// SuggestionCandidate is an account found by one source, with the count that source ranks by
+type SuggestionCandidate struct {
+	UserID uuid.UUID
+	Count  int64
+}
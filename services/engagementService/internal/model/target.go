@@ -0,0 +1,39 @@
+package model
+
+import "github.com/google/uuid"
+
+// Likeable entity types. A like points at a (type, id) pair rather than a post.
+const (
+	TargetPost    = "post"
+	TargetComment = "comment"
+	TargetRepost  = "repost"
+	TargetMedia   = "media"
+)
+
+// TargetTypes lists the entity types that can be liked
+var TargetTypes = []string{TargetPost, TargetComment, TargetRepost, TargetMedia}
+
+// IsTargetType reports whether t is one of TargetTypes
+func IsTargetType(t string) bool {
+	for _, known := range TargetTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Target identifies a likeable entity
+type Target struct {
+	Type string    `json:"target_type"`
+	ID   uuid.UUID `json:"target_id"`
+}
+
+// PostTarget is shorthand for the target of a post
+func PostTarget(postID uuid.UUID) Target {
+	return Target{Type: TargetPost, ID: postID}
+}
+
+func (t Target) String() string {
+	return t.Type + ":" + t.ID.String()
+}
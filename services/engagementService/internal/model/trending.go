@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TrendingPost is one entry of a trending list; Score is the decayed like count
+type TrendingPost struct {
+	PostID uuid.UUID `json:"post_id"`
+	Score  float64   `json:"score"`
+}
+
+// LikeBucket is the number of likes a target received in the time bucket starting at Start
+type LikeBucket struct {
+	TargetID uuid.UUID
+	Start    time.Time
+	Count    int64
+}
@@ -0,0 +1,105 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points at the last row of a page in (created_at, id) order
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode turns the cursor into an opaque token for clients
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode parses a token produced by Cursor.Encode
+func Decode(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	ts, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	micros, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: time.UnixMicro(micros).UTC(), ID: uid}, nil
+}
+
+// Params selects a page. After takes precedence over Offset, which is kept only for older clients.
+type Params struct {
+	Limit  int
+	After  *Cursor
+	Offset int // Deprecated: use After
+}
+
+// Validate checks the limit and offset bounds
+func (p Params) Validate() error {
+	if p.Limit <= 0 || p.Limit > MaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+	if p.Offset < 0 {
+		return errors.New("offset must not be negative")
+	}
+	return nil
+}
+
+// Fetch returns the params a repository should query with: one extra row tells whether more pages exist
+func (p Params) Fetch() Params {
+	p.Limit++
+	return p
+}
+
+// Page is the response envelope for paginated lists
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// NewPage builds a page from rows fetched with Params.Fetch, trimming the look-ahead row
+func NewPage[T any](rows []T, limit int, cursorOf func(T) Cursor) Page[T] {
+	page := Page[T]{Items: rows}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		page.HasMore = true
+	}
+	if page.HasMore {
+		page.NextCursor = cursorOf(page.Items[len(page.Items)-1]).Encode()
+	}
+
+	return page
+}
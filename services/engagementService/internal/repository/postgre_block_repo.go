@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// BlockRepo defines the operations on user blocks.
+type BlockRepo interface {
+	Block(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+	Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error
+	IsBlocked(ctx context.Context, a, b uuid.UUID) (bool, error)
+	List(ctx context.Context, blockerID uuid.UUID, p pagination.Params) ([]model.Block, error)
+}
+
+// PostgresBlockRepo implements BlockRepo using a PostgreSQL database.
+type PostgresBlockRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresBlockRepo creates a new PostgresBlockRepo with the given database connection.
+func NewPostgresBlockRepo(db *sql.DB) *PostgresBlockRepo {
+	return &PostgresBlockRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	insertBlockQuery = `
+		INSERT INTO blocks (blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`
+
+	// dropPairSubscriptionsQuery removes subscriptions and pending requests between the pair, in both directions
+	dropPairSubscriptionsQuery = `
+		UPDATE subscriptions
+		SET deleted_at = $3
+		WHERE ((follower_id = $1 AND followee_id = $2) OR (follower_id = $2 AND followee_id = $1))
+			AND deleted_at IS NULL
+		RETURNING follower_id, followee_id, approved
+	`
+
+	// dropPairListMembershipsQuery takes each user off the other's lists, close friends included
+	dropPairListMembershipsQuery = `
+		DELETE FROM user_list_members m
+		USING user_lists l
+		WHERE m.list_id = l.id
+			AND ((l.owner_id = $1 AND m.member_id = $2) OR (l.owner_id = $2 AND m.member_id = $1))
+		RETURNING l.id, l.owner_id, l.kind, m.member_id
+	`
+
+	// dropPairListFollowsQuery unfollows each user from the other's lists
+	dropPairListFollowsQuery = `
+		DELETE FROM user_list_followers f
+		USING user_lists l
+		WHERE f.list_id = l.id
+			AND ((l.owner_id = $1 AND f.follower_id = $2) OR (l.owner_id = $2 AND f.follower_id = $1))
+	`
+
+	deleteBlockQuery = `
+		DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2
+	`
+
+	isBlockedQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	selectBlocksQuery = `
+		SELECT blocker_id, blocked_id, created_at
+		FROM blocks
+		WHERE blocker_id = $1
+		ORDER BY created_at DESC, blocked_id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	selectBlocksAfterQuery = `
+		SELECT blocker_id, blocked_id, created_at
+		FROM blocks
+		WHERE blocker_id = $1 AND (created_at, blocked_id) < ($2, $3)
+		ORDER BY created_at DESC, blocked_id DESC
+		LIMIT $4
+	`
+)
+
+// notBlockedFilter is a WHERE condition that drops rows whose user column is in a block with the viewer,
+// whichever side created it. column is always a literal from this package, never user input.
+func notBlockedFilter(column string, viewerParam int) string {
+	return fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE (b.blocker_id = $%[2]d AND b.blocked_id = %[1]s) OR (b.blocker_id = %[1]s AND b.blocked_id = $%[2]d)
+		)`, column, viewerParam)
+}
+
+// Block records the block and tears down subscriptions, list memberships and list follows
+// between the two users in one transaction.
+// Returns false if the block already existed.
+func (r *PostgresBlockRepo) Block(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, insertBlockQuery, blockerID, blockedID, now)
+	if err != nil {
+		r.logger.WithField("blocker_id", blockerID.String()).WithError(err).Error("Block failed")
+		return false, fmt.Errorf("insert block: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("insert block: %w", err)
+	}
+	if inserted == 0 {
+		return false, nil
+	}
+
+	dropped, err := r.dropPairSubscriptions(ctx, tx, blockerID, blockedID, now)
+	if err != nil {
+		r.logger.WithField("blocker_id", blockerID.String()).WithError(err).Error("Block failed: drop subscriptions")
+		return false, err
+	}
+	for _, sub := range dropped {
+		if !sub.Approved {
+			continue // requests never became subscriptions, so nobody is told they ended
+		}
+		if err := adjustFollowStats(ctx, tx, sub.FollowerID, sub.FolloweeID, -1, now); err != nil {
+			return false, err
+		}
+		err = enqueueOutbox(ctx, tx, events.TopicSubscriptionDeleted, events.SubscriptionDeletedPayload{
+			FollowerID: sub.FollowerID,
+			FolloweeID: sub.FolloweeID,
+			DeletedAt:  now.Unix(),
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := r.dropPairLists(ctx, tx, blockerID, blockedID, now); err != nil {
+		r.logger.WithField("blocker_id", blockerID.String()).WithError(err).Error("Block failed: drop list memberships")
+		return false, err
+	}
+
+	err = enqueueOutbox(ctx, tx, events.TopicUserBlocked, events.UserBlockedPayload{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: now.Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := appendRelationshipEvent(ctx, tx, blockerID, blockedID, model.RelationshipBlock, now); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit block: %w", err)
+	}
+	return true, nil
+}
+
+func (r *PostgresBlockRepo) dropPairSubscriptions(ctx context.Context, tx *sql.Tx, a, b uuid.UUID, at time.Time) ([]model.Subscription, error) {
+	rows, err := tx.QueryContext(ctx, dropPairSubscriptionsQuery, a, b, at)
+	if err != nil {
+		return nil, fmt.Errorf("drop subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var dropped []model.Subscription
+	for rows.Next() {
+		var s model.Subscription
+		if err := rows.Scan(&s.FollowerID, &s.FolloweeID, &s.Approved); err != nil {
+			return nil, fmt.Errorf("scan dropped subscription: %w", err)
+		}
+		dropped = append(dropped, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dropped subscriptions: %w", err)
+	}
+	return dropped, nil
+}
+
+// dropPairLists removes the pair from each other's lists and list followers, announcing every removed member.
+func (r *PostgresBlockRepo) dropPairLists(ctx context.Context, tx *sql.Tx, a, b uuid.UUID, at time.Time) error {
+	rows, err := tx.QueryContext(ctx, dropPairListMembershipsQuery, a, b)
+	if err != nil {
+		return fmt.Errorf("drop list memberships: %w", err)
+	}
+	defer rows.Close()
+
+	var removed []events.ListMemberRemovedPayload
+	for rows.Next() {
+		p := events.ListMemberRemovedPayload{RemovedAt: at.Unix()}
+		if err := rows.Scan(&p.ListID, &p.OwnerID, &p.Kind, &p.MemberID); err != nil {
+			return fmt.Errorf("scan dropped list member: %w", err)
+		}
+		removed = append(removed, p)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate dropped list members: %w", err)
+	}
+	rows.Close()
+
+	for _, p := range removed {
+		if err := enqueueOutbox(ctx, tx, events.TopicListMemberRemoved, p); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, dropPairListFollowsQuery, a, b); err != nil {
+		return fmt.Errorf("drop list follows: %w", err)
+	}
+	return nil
+}
+
+// Unblock lifts a block. Subscriptions removed by the block are not restored.
+func (r *PostgresBlockRepo) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, deleteBlockQuery, blockerID, blockedID)
+	if err != nil {
+		r.logger.WithField("blocker_id", blockerID.String()).WithError(err).Error("Unblock failed")
+		return fmt.Errorf("delete block: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete block: %w", err)
+	}
+	if deleted == 0 {
+		return commonErrors.ErrNotFound
+	}
+
+	err = enqueueOutbox(ctx, tx, events.TopicUserUnblocked, events.UserUnblockedPayload{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		DeletedAt: now.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := appendRelationshipEvent(ctx, tx, blockerID, blockedID, model.RelationshipUnblock, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit unblock: %w", err)
+	}
+	return nil
+}
+
+// IsBlocked reports whether either user has blocked the other.
+func (r *PostgresBlockRepo) IsBlocked(ctx context.Context, a, b uuid.UUID) (bool, error) {
+	var blocked bool
+	if err := r.db.QueryRowContext(ctx, isBlockedQuery, a, b).Scan(&blocked); err != nil {
+		r.logger.WithError(err).Error("IsBlocked failed")
+		return false, fmt.Errorf("check block: %w", err)
+	}
+	return blocked, nil
+}
+
+// List returns the users blockerID has blocked, most recent first.
+func (r *PostgresBlockRepo) List(ctx context.Context, blockerID uuid.UUID, p pagination.Params) ([]model.Block, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if p.After != nil {
+		rows, err = r.db.QueryContext(ctx, selectBlocksAfterQuery, blockerID, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		rows, err = r.db.QueryContext(ctx, selectBlocksQuery, blockerID, p.Limit, p.Offset)
+	}
+	if err != nil {
+		r.logger.WithField("blocker_id", blockerID.String()).WithError(err).Error("List blocks failed")
+		return nil, fmt.Errorf("list blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []model.Block
+	for rows.Next() {
+		var b model.Block
+		if err := rows.Scan(&b.BlockerID, &b.BlockedID, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan block: %w", err)
+		}
+		blocks = append(blocks, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate blocks: %w", err)
+	}
+	return blocks, nil
+}
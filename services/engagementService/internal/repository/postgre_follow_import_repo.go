@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"engagementService/internal/model"
+	"errors"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"time"
+
+	commonErrors "engagementService/internal/errors"
+)
+
+// FollowImportRepo stores bulk follow import jobs.
+type FollowImportRepo interface {
+	Create(ctx context.Context, job *model.FollowImportJob) error
+	Get(ctx context.Context, id uuid.UUID) (*model.FollowImportJob, error)
+	ClaimNext(ctx context.Context, lease time.Duration) (*model.FollowImportJob, error)
+	Update(ctx context.Context, job *model.FollowImportJob) error
+}
+
+// PostgresFollowImportRepo implements FollowImportRepo using a PostgreSQL database.
+type PostgresFollowImportRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresFollowImportRepo creates a new PostgresFollowImportRepo with the given database connection.
+func NewPostgresFollowImportRepo(db *sql.DB) *PostgresFollowImportRepo {
+	return &PostgresFollowImportRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	followImportColumns = `id, user_id, status, user_ids, total, processed, followed, requested, skipped,
+		last_error, created_at, updated_at, finished_at, resume_at`
+
+	// u_follow_import_active lets a user have one unfinished job at a time
+	insertFollowImportQuery = `
+		INSERT INTO follow_import_jobs (id, user_id, status, user_ids, total, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (user_id) WHERE status IN ('pending', 'running') DO NOTHING
+	`
+
+	selectFollowImportQuery = `
+		SELECT ` + followImportColumns + `
+		FROM follow_import_jobs
+		WHERE id = $1
+	`
+
+	// claimFollowImportQuery picks the oldest pending job that isn't waiting for the rate limit, or a running one
+	// whose worker stopped reporting progress for longer than the lease, e.g. because the replica was restarted.
+	claimFollowImportQuery = `
+		UPDATE follow_import_jobs
+		SET status = 'running', updated_at = $1, resume_at = NULL
+		WHERE id = (
+			SELECT id
+			FROM follow_import_jobs
+			WHERE (status = 'pending' AND (resume_at IS NULL OR resume_at <= $1))
+				OR (status = 'running' AND updated_at < $2)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + followImportColumns + `
+	`
+
+	updateFollowImportQuery = `
+		UPDATE follow_import_jobs
+		SET status = $1, processed = $2, followed = $3, requested = $4, skipped = $5,
+		    last_error = $6, updated_at = $7, finished_at = $8, resume_at = $9
+		WHERE id = $10
+	`
+)
+
+// Create stores a new pending job. Returns ErrImportInProgress if the user already has an unfinished one.
+func (r *PostgresFollowImportRepo) Create(ctx context.Context, job *model.FollowImportJob) error {
+	strIDs := make([]string, len(job.UserIDs))
+	for i, id := range job.UserIDs {
+		strIDs[i] = id.String()
+	}
+
+	result, err := r.db.ExecContext(ctx, insertFollowImportQuery,
+		job.ID, job.UserID, job.Status, pq.Array(strIDs), job.Total, job.CreatedAt)
+	if err != nil {
+		r.logger.WithField("user_id", job.UserID.String()).WithError(err).Error("Create follow import failed")
+		return fmt.Errorf("insert follow import: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("insert follow import: %w", err)
+	}
+	if inserted == 0 {
+		return commonErrors.ErrImportInProgress
+	}
+	return nil
+}
+
+// Get returns a job by ID, or ErrNotFound.
+func (r *PostgresFollowImportRepo) Get(ctx context.Context, id uuid.UUID) (*model.FollowImportJob, error) {
+	job, err := scanFollowImport(r.db.QueryRowContext(ctx, selectFollowImportQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, commonErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get follow import: %w", err)
+	}
+	return job, nil
+}
+
+// ClaimNext marks the next due job as running and returns it, or nil if there is none.
+func (r *PostgresFollowImportRepo) ClaimNext(ctx context.Context, lease time.Duration) (*model.FollowImportJob, error) {
+	now := time.Now().UTC()
+	job, err := scanFollowImport(r.db.QueryRowContext(ctx, claimFollowImportQuery, now, now.Add(-lease)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("ClaimNext follow import failed")
+		return nil, fmt.Errorf("claim follow import: %w", err)
+	}
+	return job, nil
+}
+
+// Update saves the job's status and progress. It also renews the lease of a running job.
+func (r *PostgresFollowImportRepo) Update(ctx context.Context, job *model.FollowImportJob) error {
+	_, err := r.db.ExecContext(ctx, updateFollowImportQuery, job.Status, job.Processed, job.Followed, job.Requested,
+		job.Skipped, job.LastError, job.UpdatedAt, job.FinishedAt, job.ResumeAt, job.ID)
+	if err != nil {
+		r.logger.WithField("job_id", job.ID.String()).WithError(err).Error("Update follow import failed")
+		return fmt.Errorf("update follow import: %w", err)
+	}
+	return nil
+}
+
+func scanFollowImport(row rowScanner) (*model.FollowImportJob, error) {
+	job := &model.FollowImportJob{}
+	var userIDs []string
+	var lastError sql.NullString
+	var finishedAt, resumeAt sql.NullTime
+	if err := row.Scan(&job.ID, &job.UserID, &job.Status, pq.Array(&userIDs), &job.Total, &job.Processed,
+		&job.Followed, &job.Requested, &job.Skipped, &lastError, &job.CreatedAt, &job.UpdatedAt, &finishedAt, &resumeAt); err != nil {
+		return nil, err
+	}
+
+	job.UserIDs = make([]uuid.UUID, 0, len(userIDs))
+	for _, s := range userIDs {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse imported user id: %w", err)
+		}
+		job.UserIDs = append(job.UserIDs, id)
+	}
+	if lastError.Valid {
+		job.LastError = &lastError.String
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	if resumeAt.Valid {
+		job.ResumeAt = &resumeAt.Time
+	}
+	return job, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"engagementService/internal/model"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"time"
+)
+
+// FollowStatsRepo reads the user_follow_stats counters. They are written by the repositories
+// that change subscriptions, in the same transaction, through adjustFollowStats.
+type FollowStatsRepo interface {
+	GetMany(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]model.FollowStats, error)
+}
+
+// PostgresFollowStatsRepo implements FollowStatsRepo using a PostgreSQL database.
+type PostgresFollowStatsRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresFollowStatsRepo creates a new PostgresFollowStatsRepo with the given database connection.
+func NewPostgresFollowStatsRepo(db *sql.DB) *PostgresFollowStatsRepo {
+	return &PostgresFollowStatsRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	adjustFollowStatsQuery = `
+		INSERT INTO user_follow_stats (user_id, followers_count, following_count, updated_at)
+		VALUES ($1, GREATEST($2, 0), GREATEST($3, 0), $4)
+		ON CONFLICT (user_id) DO UPDATE
+			SET followers_count = GREATEST(user_follow_stats.followers_count + $2, 0),
+			    following_count = GREATEST(user_follow_stats.following_count + $3, 0),
+			    updated_at = EXCLUDED.updated_at
+	`
+
+	selectFollowStatsQuery = `
+		SELECT user_id, followers_count, following_count
+		FROM user_follow_stats
+		WHERE user_id = ANY($1::uuid[])
+	`
+)
+
+// followStatsChange moves one user's counters.
+type followStatsChange struct {
+	userID               uuid.UUID
+	followers, following int64
+}
+
+// adjustFollowStats moves the counters of both sides of a subscription by delta inside the caller's transaction.
+func adjustFollowStats(ctx context.Context, tx *sql.Tx, followerID, followeeID uuid.UUID, delta int64, at time.Time) error {
+	return applyFollowStats(ctx, tx, []followStatsChange{
+		{userID: followeeID, followers: delta},
+		{userID: followerID, following: delta},
+	}, at)
+}
+
+// applyFollowStats writes counter changes inside the caller's transaction.
+// Rows are locked in user ID order, so two users following each other at once can't deadlock.
+func applyFollowStats(ctx context.Context, tx *sql.Tx, changes []followStatsChange, at time.Time) error {
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].userID[:], changes[j].userID[:]) < 0
+	})
+
+	for _, c := range changes {
+		if _, err := tx.ExecContext(ctx, adjustFollowStatsQuery, c.userID, c.followers, c.following, at); err != nil {
+			return fmt.Errorf("adjust follow stats: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetMany returns the counters of the given users. Users nobody ever followed and who never followed
+// anyone have no row; they get zero counts.
+func (r *PostgresFollowStatsRepo) GetMany(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]model.FollowStats, error) {
+	stats := make(map[uuid.UUID]model.FollowStats, len(userIDs))
+	if len(userIDs) == 0 {
+		return stats, nil
+	}
+
+	strIDs := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		strIDs[i] = id.String()
+		stats[id] = model.FollowStats{UserID: id}
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectFollowStatsQuery, pq.Array(strIDs))
+	if err != nil {
+		r.logger.WithError(err).Error("GetMany follow stats failed")
+		return nil, fmt.Errorf("get follow stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s model.FollowStats
+		if err := rows.Scan(&s.UserID, &s.Followers, &s.Following); err != nil {
+			return nil, fmt.Errorf("scan follow stats: %w", err)
+		}
+		stats[s.UserID] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate follow stats: %w", err)
+	}
+	return stats, nil
+}
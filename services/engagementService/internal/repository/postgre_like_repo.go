@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	commonErrors "engagementService/internal/errors"
 	"engagementService/internal/model"
+	"engagementService/internal/pagination"
 	"errors"
 	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/events"
 	"github.com/Sayan80bayev/go-project/pkg/logging"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	// Removed "go.uber.org/zap"
 	"time"
@@ -16,12 +19,22 @@ import (
 
 // LikeRepo defines the interface for like-related database operations.
 type LikeRepo interface {
-	Create(ctx context.Context, s *model.Like) (*model.Like, error)
+	Create(ctx context.Context, s *model.Like) (*model.Like, bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Like, error)
-	GetByUserID(ctx context.Context, id uuid.UUID, limit, offset int) ([]*model.Like, error)
-	GetByPostID(ctx context.Context, postID uuid.UUID, limit, offset int) ([]*model.Like, error)
-	Delete(ctx context.Context, id uuid.UUID, userId uuid.UUID) error
+	GetByUserID(ctx context.Context, id uuid.UUID, p pagination.Params) ([]*model.Like, error)
+	GetByTarget(ctx context.Context, t model.Target, viewerID uuid.UUID, p pagination.Params) ([]*model.Like, error)
+	Delete(ctx context.Context, id uuid.UUID, userId uuid.UUID) (*model.Like, error)
+	DeleteByTarget(ctx context.Context, userID uuid.UUID, t model.Target) (*model.Like, error)
 	HardDelete(ctx context.Context, id uuid.UUID, userId uuid.UUID) error
+
+	CountByTarget(ctx context.Context, t model.Target) (int64, error)
+	CountByTargets(ctx context.Context, targetType string, ids []uuid.UUID) (map[uuid.UUID]int64, error)
+	ReconcileCounts(ctx context.Context) ([]model.Target, error)
+	CountReactionsByTarget(ctx context.Context, t model.Target) (map[string]int64, error)
+
+	GetLikedTargetIDs(ctx context.Context, userID uuid.UUID, targetType string, ids []uuid.UUID) (map[uuid.UUID]uuid.UUID, error)
+
+	CountCreatedSince(ctx context.Context, targetType string, since time.Time, bucket time.Duration) ([]model.LikeBucket, error)
 }
 
 // PostgresLikeRepo implements LikeRepo using a PostgreSQL database.
@@ -36,94 +49,279 @@ func NewPostgresLikeRepo(db *sql.DB) *PostgresLikeRepo {
 }
 
 const (
+	lockLikeQuery = `
+		SELECT id, user_id, target_type, target_id, reaction, created_at, deleted_at
+		FROM likes
+		WHERE user_id = $1 AND target_type = $2 AND target_id = $3
+		FOR UPDATE
+	`
+
 	insertLikeQuery = `
-		INSERT INTO likes (id, user_id, post_id, created_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, user_id, post_id, created_at, deleted_at
+		INSERT INTO likes (id, user_id, target_type, target_id, reaction, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, target_type, target_id) DO NOTHING
+		RETURNING id, user_id, target_type, target_id, reaction, created_at, deleted_at
+	`
+
+	restoreLikeQuery = `
+		UPDATE likes SET deleted_at = NULL, created_at = $1, reaction = $2
+		WHERE id = $3
+		RETURNING id, user_id, target_type, target_id, reaction, created_at, deleted_at
+	`
+
+	updateReactionQuery = `
+		UPDATE likes SET reaction = $1
+		WHERE id = $2
+		RETURNING id, user_id, target_type, target_id, reaction, created_at, deleted_at
 	`
 
+	countReactionsQuery = `
+		SELECT reaction, COUNT(*)
+		FROM likes
+		WHERE target_type = $1 AND target_id = $2 AND deleted_at IS NULL
+		GROUP BY reaction
+	`
+
+	// selectBaseQuery pages with OFFSET; kept for clients that haven't moved to cursors.
+	// The filter binds the first n parameters and the paging parameters follow it.
 	selectBaseQuery = `
-		SELECT id, user_id, post_id, created_at, deleted_at
+		SELECT id, user_id, target_type, target_id, reaction, created_at, deleted_at
 		FROM likes
-		WHERE %s = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		WHERE %[1]s AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%[2]d OFFSET $%[3]d
+	`
+
+	selectAfterQuery = `
+		SELECT id, user_id, target_type, target_id, reaction, created_at, deleted_at
+		FROM likes
+		WHERE %[1]s AND deleted_at IS NULL AND (created_at, id) < ($%[2]d, $%[3]d)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%[4]d
 	`
 
 	selectByIDQuery = `
-		SELECT id, user_id, post_id, created_at, deleted_at
+		SELECT id, user_id, target_type, target_id, reaction, created_at, deleted_at
 		FROM likes
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	softDeleteQuery = `
 		UPDATE likes SET deleted_at = $1 WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
+		RETURNING id, target_type, target_id, reaction, created_at
+	`
+
+	softDeleteByTargetQuery = `
+		UPDATE likes SET deleted_at = $1
+		WHERE user_id = $2 AND target_type = $3 AND target_id = $4 AND deleted_at IS NULL
+		RETURNING id, target_type, target_id, reaction, created_at
 	`
 
 	hardDeleteQuery = `
-		DELETE FROM likes WHERE id = $1 AND user_id = $3
+		DELETE FROM likes WHERE id = $1 AND user_id = $2
+	`
+
+	incrementLikeCountQuery = `
+		INSERT INTO like_counts (target_type, target_id, like_count, updated_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (target_type, target_id) DO UPDATE
+			SET like_count = like_counts.like_count + 1, updated_at = EXCLUDED.updated_at
+	`
+
+	decrementLikeCountQuery = `
+		UPDATE like_counts
+		SET like_count = GREATEST(like_count - 1, 0), updated_at = $3
+		WHERE target_type = $1 AND target_id = $2
+	`
+
+	selectLikeCountQuery = `
+		SELECT like_count FROM like_counts WHERE target_type = $1 AND target_id = $2
+	`
+
+	selectLikeCountsQuery = `
+		SELECT target_id, like_count FROM like_counts WHERE target_type = $1 AND target_id = ANY($2::uuid[])
+	`
+
+	selectLikedTargetsQuery = `
+		SELECT target_id, id
+		FROM likes
+		WHERE user_id = $1 AND target_type = $2 AND target_id = ANY($3::uuid[]) AND deleted_at IS NULL
+	`
+
+	// countCreatedSinceQuery groups active likes into fixed-width buckets of $3 seconds
+	countCreatedSinceQuery = `
+		SELECT target_id,
+		       to_timestamp(floor(extract(epoch FROM created_at) / $3) * $3) AS bucket,
+		       COUNT(*)
+		FROM likes
+		WHERE target_type = $1 AND created_at >= $2 AND deleted_at IS NULL
+		GROUP BY target_id, bucket
+	`
+
+	// selectDriftedLikeCountsQuery finds the counters that disagree with the likes table. It reads a snapshot,
+	// so each target is recounted under a row lock before its counter is written.
+	selectDriftedLikeCountsQuery = `
+		WITH actual AS (
+			SELECT target_type, target_id, COUNT(*) AS cnt
+			FROM likes
+			WHERE deleted_at IS NULL
+			GROUP BY target_type, target_id
+		)
+		SELECT COALESCE(a.target_type, lc.target_type), COALESCE(a.target_id, lc.target_id)
+		FROM actual a
+		FULL OUTER JOIN like_counts lc ON lc.target_type = a.target_type AND lc.target_id = a.target_id
+		WHERE lc.target_id IS NULL OR lc.like_count <> COALESCE(a.cnt, 0)
+	`
+
+	ensureLikeCountQuery = `
+		INSERT INTO like_counts (target_type, target_id, like_count, updated_at)
+		VALUES ($1, $2, 0, $3)
+		ON CONFLICT (target_type, target_id) DO NOTHING
+	`
+
+	lockLikeCountQuery = `
+		SELECT like_count FROM like_counts WHERE target_type = $1 AND target_id = $2 FOR UPDATE
+	`
+
+	countActiveLikesQuery = `
+		SELECT COUNT(*) FROM likes WHERE target_type = $1 AND target_id = $2 AND deleted_at IS NULL
+	`
+
+	setLikeCountQuery = `
+		UPDATE like_counts SET like_count = $3, updated_at = $4 WHERE target_type = $1 AND target_id = $2
 	`
 )
 
-// Create inserts a new like into the database.
-// Returns an error if the like already exists or if user_id or post_id is empty.
-func (r *PostgresLikeRepo) Create(ctx context.Context, s *model.Like) (*model.Like, error) {
+// Create likes s.Target() on behalf of a user with the reaction in s.Reaction, or the default if it is empty.
+// A previously removed like is restored with a fresh created_at, and an active like with a different
+// reaction is changed in place. Repeating the same reaction, or giving none, is idempotent and returns
+// the existing like, so older clients that don't send a reaction can't overwrite one.
+// The boolean result reports whether a like was added, i.e. whether the target's like count went up.
+func (r *PostgresLikeRepo) Create(ctx context.Context, s *model.Like) (*model.Like, bool, error) {
 	if s == nil {
 		r.logger.Error("Create like failed: nil like")
-		return nil, commonErrors.ErrInvalidArgument
+		return nil, false, commonErrors.ErrInvalidArgument
 	}
 	if s.UserID == uuid.Nil {
 		r.logger.Error("Create like failed: empty user ID")
-		return nil, commonErrors.ErrInvalidArgument
+		return nil, false, commonErrors.ErrInvalidArgument
 	}
-	if s.PostID == uuid.Nil {
-		r.logger.Error("Create like failed: empty post ID")
-		return nil, commonErrors.ErrInvalidArgument
+	if s.TargetType == "" || s.TargetID == uuid.Nil {
+		r.logger.Error("Create like failed: empty target")
+		return nil, false, commonErrors.ErrInvalidArgument
+	}
+	reaction := s.Reaction
+	if reaction == "" {
+		reaction = model.DefaultReaction
 	}
 
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		r.logger.WithError(err).Error("Create like failed: begin transaction")
-		return nil, fmt.Errorf("begin transaction: %w", err)
+		return nil, false, fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	now := time.Now().UTC()
-	newLike := &model.Like{
-		ID:        s.ID,
-		UserID:    s.UserID,
-		PostID:    s.PostID,
-		CreatedAt: now,
-		DeletedAt: nil,
-	}
 
-	if newLike.ID == uuid.Nil {
-		newLike.ID = uuid.New()
-	}
+	// The insert only loses a race to a concurrent like of the same pair once;
+	// after that the row exists and is locked on the second pass.
+	var like *model.Like
+	var eventType string
+	for attempt := 0; attempt < 2 && like == nil; attempt++ {
+		existing, err := scanLike(tx.QueryRowContext(ctx, lockLikeQuery, s.UserID, s.TargetType, s.TargetID))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			r.logger.WithError(err).Error("Create like failed: lock like")
+			return nil, false, fmt.Errorf("lock like: %w", err)
+		}
 
-	err = tx.QueryRowContext(ctx, insertLikeQuery,
-		newLike.ID, newLike.UserID, newLike.PostID, newLike.CreatedAt).
-		Scan(&newLike.ID, &newLike.UserID, &newLike.PostID, &newLike.CreatedAt, &newLike.DeletedAt)
+		switch {
+		case existing == nil:
+			id := s.ID
+			if id == uuid.Nil {
+				id = uuid.New()
+			}
+			like, err = scanLike(tx.QueryRowContext(ctx, insertLikeQuery, id, s.UserID, s.TargetType, s.TargetID, reaction, now))
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			eventType = events.TopicLikeCreated
+		case existing.DeletedAt != nil:
+			like, err = scanLike(tx.QueryRowContext(ctx, restoreLikeQuery, now, reaction, existing.ID))
+			eventType = events.TopicLikeCreated
+		case s.Reaction == "" || existing.Reaction == reaction:
+			r.logger.WithField("id", existing.ID.String()).Debug("Like already exists")
+			return existing, false, nil
+		default:
+			like, err = scanLike(tx.QueryRowContext(ctx, updateReactionQuery, reaction, existing.ID))
+			eventType = events.TopicLikeUpdated
+		}
+		if err != nil {
+			r.logger.WithError(err).Error("Create like failed")
+			return nil, false, fmt.Errorf("create like: %w", err)
+		}
+	}
+	if like == nil {
+		r.logger.WithFields(logrus.Fields{
+			"user_id": s.UserID.String(),
+			"target":  s.Target().String(),
+		}).Error("Create like failed: concurrent update")
+		return nil, false, fmt.Errorf("create like: concurrent update of user %s and %s", s.UserID, s.Target())
+	}
 
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			r.logger.WithFields(logrus.Fields{
-				"user_id": s.UserID.String(),
-				"post_id": s.PostID.String(),
-			}).Error("Create like failed: duplicate like")
-			return nil, commonErrors.ErrDuplicateLike
+	created := eventType == events.TopicLikeCreated
+	if created {
+		if _, err := tx.ExecContext(ctx, incrementLikeCountQuery, like.TargetType, like.TargetID, now); err != nil {
+			r.logger.WithError(err).Error("Create like failed: increment like count")
+			return nil, false, fmt.Errorf("increment like count: %w", err)
 		}
-		r.logger.WithError(err).Error("Create like failed")
-		return nil, fmt.Errorf("create like: %w", err)
+	}
+
+	if err := enqueueOutbox(ctx, tx, eventType, newLikeEvent(like)); err != nil {
+		r.logger.WithError(err).Error("Create like failed: enqueue event")
+		return nil, false, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		r.logger.WithError(err).Error("Create like failed: commit transaction")
-		return nil, fmt.Errorf("commit transaction: %w", err)
+		return nil, false, fmt.Errorf("commit transaction: %w", err)
 	}
 
-	r.logger.WithField("id", newLike.ID.String()).Info("Like created")
-	return newLike, nil
+	r.logger.WithFields(logrus.Fields{"id": like.ID.String(), "event": eventType}).Info("Like saved")
+	return like, created, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanLike reads a like selected as id, user_id, target_type, target_id, reaction, created_at, deleted_at.
+func scanLike(row rowScanner) (*model.Like, error) {
+	like := &model.Like{}
+	if err := row.Scan(&like.ID, &like.UserID, &like.TargetType, &like.TargetID, &like.Reaction,
+		&like.CreatedAt, &like.DeletedAt); err != nil {
+		return nil, err
+	}
+	return like, nil
+}
+
+// newLikeEvent builds the outbox payload for a like.
+func newLikeEvent(like *model.Like) events.LikeEvent {
+	e := events.LikeEvent{
+		ID:         like.ID,
+		UserID:     like.UserID,
+		TargetType: like.TargetType,
+		TargetID:   like.TargetID,
+		Reaction:   like.Reaction,
+		CreatedAt:  like.CreatedAt,
+		DeletedAt:  like.DeletedAt,
+	}
+	if like.TargetType == model.TargetPost {
+		postID := like.TargetID
+		e.PostID = &postID
+	}
+	return e
 }
 
 // GetByID retrieves a like by its ID.
@@ -134,10 +332,7 @@ func (r *PostgresLikeRepo) GetByID(ctx context.Context, id uuid.UUID) (*model.Li
 		return nil, commonErrors.ErrInvalidArgument
 	}
 
-	like := &model.Like{}
-	err := r.db.QueryRowContext(ctx, selectByIDQuery, id).
-		Scan(&like.ID, &like.UserID, &like.PostID, &like.CreatedAt, &like.DeletedAt)
-
+	like, err := scanLike(r.db.QueryRowContext(ctx, selectByIDQuery, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			r.logger.WithField("id", id.String()).Error("GetLikeByID failed: like not found")
@@ -150,128 +345,137 @@ func (r *PostgresLikeRepo) GetByID(ctx context.Context, id uuid.UUID) (*model.Li
 	return like, nil
 }
 
-// GetByUserID retrieves all likes by a user with pagination.
-// Returns an error if the user ID is empty or no likes are found.
-func (r *PostgresLikeRepo) GetByUserID(ctx context.Context, id uuid.UUID, limit, offset int) ([]*model.Like, error) {
+// GetByUserID retrieves likes by a user on any target, newest first.
+// Returns an error if the user ID is empty or the pagination parameters are invalid.
+func (r *PostgresLikeRepo) GetByUserID(ctx context.Context, id uuid.UUID, p pagination.Params) ([]*model.Like, error) {
 	if id == uuid.Nil {
 		r.logger.Error("GetByUserID failed: empty user ID")
 		return nil, commonErrors.ErrInvalidArgument
 	}
-	if limit <= 0 || offset < 0 {
-		r.logger.WithFields(logrus.Fields{
-			"limit":  limit,
-			"offset": offset,
-		}).Error("GetByUserID failed: invalid pagination parameters")
-		return nil, commonErrors.ErrInvalidArgument
-	}
 
-	query := fmt.Sprintf(selectBaseQuery, "user_id")
-	rows, err := r.db.QueryContext(ctx, query, id, limit, offset)
-	if err != nil {
-		r.logger.WithError(err).WithField("user_id", id.String()).Error("GetByUserID failed")
-		return nil, fmt.Errorf("get likes by user_id: %w", err)
-	}
-	defer rows.Close()
+	return r.list(ctx, p, "user_id = $1", id)
+}
 
-	var likes []*model.Like
-	for rows.Next() {
-		like := &model.Like{}
-		if err := rows.Scan(&like.ID, &like.UserID, &like.PostID, &like.CreatedAt, &like.DeletedAt); err != nil {
-			r.logger.WithError(err).Error("GetByUserID failed: scan like")
-			return nil, fmt.Errorf("scan like: %w", err)
-		}
-		likes = append(likes, like)
+// GetByTarget retrieves likes on a target, newest first.
+// Likes by users in a block with viewerID are left out; uuid.Nil returns all of them.
+// Returns an error if the target is empty or the pagination parameters are invalid.
+func (r *PostgresLikeRepo) GetByTarget(ctx context.Context, t model.Target, viewerID uuid.UUID, p pagination.Params) ([]*model.Like, error) {
+	if t.Type == "" || t.ID == uuid.Nil {
+		r.logger.Error("GetByTarget failed: empty target")
+		return nil, commonErrors.ErrInvalidArgument
 	}
 
-	if len(likes) == 0 {
-		r.logger.WithField("user_id", id.String()).Error("GetByUserID failed: no likes found")
-		return nil, commonErrors.ErrNotFound
+	if viewerID != uuid.Nil {
+		return r.list(ctx, p, "target_type = $1 AND target_id = $2 AND "+notBlockedFilter("likes.user_id", 3), t.Type, t.ID, viewerID)
 	}
-
-	return likes, nil
+	return r.list(ctx, p, "target_type = $1 AND target_id = $2", t.Type, t.ID)
 }
 
-// GetByPostID retrieves all likes for a post with pagination.
-// Returns an error if the post ID is empty or no likes are found.
-func (r *PostgresLikeRepo) GetByPostID(ctx context.Context, postID uuid.UUID, limit, offset int) ([]*model.Like, error) {
-	if postID == uuid.Nil {
-		r.logger.Error("GetByPostID failed: empty post ID")
-		return nil, commonErrors.ErrInvalidArgument
-	}
-	if limit <= 0 || offset < 0 {
+// list pages through active likes matching filter, using the cursor when one is given.
+// filter may only reference $1..$len(args) and is always a literal from this file, never user input.
+func (r *PostgresLikeRepo) list(ctx context.Context, p pagination.Params, filter string, args ...interface{}) ([]*model.Like, error) {
+	if p.Limit <= 0 || p.Offset < 0 {
 		r.logger.WithFields(logrus.Fields{
-			"limit":  limit,
-			"offset": offset,
-		}).Error("GetByPostID failed: invalid pagination parameters")
+			"limit":  p.Limit,
+			"offset": p.Offset,
+		}).Errorf("List likes by %s failed: invalid pagination parameters", filter)
 		return nil, commonErrors.ErrInvalidArgument
 	}
 
-	query := fmt.Sprintf(selectBaseQuery, "post_id")
-	rows, err := r.db.QueryContext(ctx, query, postID, limit, offset)
+	n := len(args)
+	var query string
+	if p.After != nil {
+		query = fmt.Sprintf(selectAfterQuery, filter, n+1, n+2, n+3)
+		args = append(args, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		query = fmt.Sprintf(selectBaseQuery, filter, n+1, n+2)
+		args = append(args, p.Limit, p.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		r.logger.WithError(err).WithField("post_id", postID.String()).Error("GetByPostID failed")
-		return nil, fmt.Errorf("get likes by post_id: %w", err)
+		r.logger.WithError(err).WithField("args", args[:n]).Errorf("List likes by %s failed", filter)
+		return nil, fmt.Errorf("list likes: %w", err)
 	}
 	defer rows.Close()
 
 	var likes []*model.Like
 	for rows.Next() {
-		like := &model.Like{}
-		if err := rows.Scan(&like.ID, &like.UserID, &like.PostID, &like.CreatedAt, &like.DeletedAt); err != nil {
-			r.logger.WithError(err).Error("GetByPostID failed: scan like")
+		like, err := scanLike(rows)
+		if err != nil {
+			r.logger.WithError(err).Errorf("List likes by %s failed: scan like", filter)
 			return nil, fmt.Errorf("scan like: %w", err)
 		}
 		likes = append(likes, like)
 	}
-
-	if len(likes) == 0 {
-		r.logger.WithField("post_id", postID.String()).Error("GetByPostID failed: no likes found")
-		return nil, commonErrors.ErrNotFound
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate likes: %w", err)
 	}
 
 	return likes, nil
 }
 
-// Delete soft-deletes a like by its ID.
+// Delete soft-deletes a like by its ID and returns the removed like.
 // Returns an error if the ID is empty or the like is not found.
-func (r *PostgresLikeRepo) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+func (r *PostgresLikeRepo) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*model.Like, error) {
 	if id == uuid.Nil {
 		r.logger.Error("Unlike failed: empty ID")
-		return commonErrors.ErrInvalidArgument
+		return nil, commonErrors.ErrInvalidArgument
 	}
 
+	return r.softDelete(ctx, userID, softDeleteQuery, id, userID)
+}
+
+// DeleteByTarget soft-deletes the user's like on a target and returns the removed like.
+// Returns an error if an ID is empty or the target is not liked by the user.
+func (r *PostgresLikeRepo) DeleteByTarget(ctx context.Context, userID uuid.UUID, t model.Target) (*model.Like, error) {
+	if userID == uuid.Nil || t.Type == "" || t.ID == uuid.Nil {
+		r.logger.Error("Unlike failed: empty ID")
+		return nil, commonErrors.ErrInvalidArgument
+	}
+
+	return r.softDelete(ctx, userID, softDeleteByTargetQuery, userID, t.Type, t.ID)
+}
+
+// softDelete runs one of the soft delete queries and records the like.deleted event in the same transaction.
+func (r *PostgresLikeRepo) softDelete(ctx context.Context, userID uuid.UUID, query string, args ...interface{}) (*model.Like, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		r.logger.WithError(err).Error("Unlike failed: begin transaction")
-		return fmt.Errorf("begin transaction: %w", err)
+		return nil, fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	now := time.Now().UTC()
-	result, err := tx.ExecContext(ctx, softDeleteQuery, now, id, userID)
+	like := &model.Like{UserID: userID, DeletedAt: &now}
+	err = tx.QueryRowContext(ctx, query, append([]interface{}{now}, args...)...).
+		Scan(&like.ID, &like.TargetType, &like.TargetID, &like.Reaction, &like.CreatedAt)
 	if err != nil {
-		r.logger.WithError(err).WithField("id", id.String()).Error("Unlike failed")
-		return fmt.Errorf("delete like: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.WithField("args", args).Error("Unlike failed: like not found")
+			return nil, commonErrors.ErrNotFound
+		}
+		r.logger.WithError(err).WithField("args", args).Error("Unlike failed")
+		return nil, fmt.Errorf("delete like: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		r.logger.WithError(err).Error("Unlike failed: check rows affected")
-		return fmt.Errorf("delete like: %w", err)
+	if _, err := tx.ExecContext(ctx, decrementLikeCountQuery, like.TargetType, like.TargetID, now); err != nil {
+		r.logger.WithError(err).Error("Unlike failed: decrement like count")
+		return nil, fmt.Errorf("decrement like count: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		r.logger.WithField("id", id.String()).Error("Unlike failed: like not found")
-		return commonErrors.ErrNotFound
+	if err := enqueueOutbox(ctx, tx, events.TopicLikeDeleted, newLikeEvent(like)); err != nil {
+		r.logger.WithError(err).Error("Unlike failed: enqueue event")
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
 		r.logger.WithError(err).Error("Unlike failed: commit transaction")
-		return fmt.Errorf("commit transaction: %w", err)
+		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
 
-	r.logger.WithField("id", id.String()).Info("Like deleted")
-	return nil
+	r.logger.WithField("id", like.ID.String()).Info("Like deleted")
+	return like, nil
 }
 
 // HardDelete permanently deletes a like by its ID.
@@ -314,3 +518,236 @@ func (r *PostgresLikeRepo) HardDelete(ctx context.Context, id uuid.UUID, userId
 	r.logger.WithField("id", id.String()).Info("Like hard deleted")
 	return nil
 }
+
+// CountByTarget returns the number of active likes on a target from the like_counts aggregate.
+func (r *PostgresLikeRepo) CountByTarget(ctx context.Context, t model.Target) (int64, error) {
+	if t.Type == "" || t.ID == uuid.Nil {
+		r.logger.Error("CountByTarget failed: empty target")
+		return 0, commonErrors.ErrInvalidArgument
+	}
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, selectLikeCountQuery, t.Type, t.ID).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		r.logger.WithError(err).WithField("target", t.String()).Error("CountByTarget failed")
+		return 0, fmt.Errorf("count likes by target: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByTargets returns like counts for many targets of one type in one query.
+// Targets without likes are present in the result with a zero count.
+func (r *PostgresLikeRepo) CountByTargets(ctx context.Context, targetType string, ids []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		counts[id] = 0
+		strIDs[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectLikeCountsQuery, targetType, pq.Array(strIDs))
+	if err != nil {
+		r.logger.WithError(err).WithField("target_type", targetType).Error("CountByTargets failed")
+		return nil, fmt.Errorf("count likes by targets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var targetID uuid.UUID
+		var count int64
+		if err := rows.Scan(&targetID, &count); err != nil {
+			r.logger.WithError(err).Error("CountByTargets failed: scan count")
+			return nil, fmt.Errorf("scan like count: %w", err)
+		}
+		counts[targetID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate like counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// ReconcileCounts recomputes drifted like counters from the likes table.
+// Returns the targets whose counter was corrected.
+func (r *PostgresLikeRepo) ReconcileCounts(ctx context.Context) ([]model.Target, error) {
+	rows, err := r.db.QueryContext(ctx, selectDriftedLikeCountsQuery)
+	if err != nil {
+		r.logger.WithError(err).Error("ReconcileCounts failed")
+		return nil, fmt.Errorf("find drifted like counts: %w", err)
+	}
+	defer rows.Close()
+
+	var drifted []model.Target
+	for rows.Next() {
+		var t model.Target
+		if err := rows.Scan(&t.Type, &t.ID); err != nil {
+			r.logger.WithError(err).Error("ReconcileCounts failed: scan target")
+			return nil, fmt.Errorf("scan target: %w", err)
+		}
+		drifted = append(drifted, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate drifted counts: %w", err)
+	}
+	rows.Close()
+
+	var fixed []model.Target
+	for _, t := range drifted {
+		changed, err := r.reconcileCount(ctx, t)
+		if err != nil {
+			r.logger.WithError(err).WithField("target", t.String()).Error("ReconcileCounts failed")
+			return fixed, fmt.Errorf("reconcile like count: %w", err)
+		}
+		if changed {
+			fixed = append(fixed, t)
+		}
+	}
+
+	return fixed, nil
+}
+
+// reconcileCount recounts one target while holding its counter's row lock. Likes and unlikes change
+// the counter in the same transaction as the likes row, so the recount sees every change that
+// already touched the counter, and changes still in flight apply their +1/-1 on top of it afterwards.
+func (r *PostgresLikeRepo) reconcileCount(ctx context.Context, t model.Target) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	if _, err := tx.ExecContext(ctx, ensureLikeCountQuery, t.Type, t.ID, now); err != nil {
+		return false, fmt.Errorf("ensure like count: %w", err)
+	}
+
+	var stored, actual int64
+	if err := tx.QueryRowContext(ctx, lockLikeCountQuery, t.Type, t.ID).Scan(&stored); err != nil {
+		return false, fmt.Errorf("lock like count: %w", err)
+	}
+	if err := tx.QueryRowContext(ctx, countActiveLikesQuery, t.Type, t.ID).Scan(&actual); err != nil {
+		return false, fmt.Errorf("count likes: %w", err)
+	}
+	if stored == actual {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, setLikeCountQuery, t.Type, t.ID, actual, now); err != nil {
+		return false, fmt.Errorf("set like count: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// GetLikedTargetIDs returns, for the given targets of one type, the ones the user has liked mapped to the like ID.
+// Targets the user hasn't liked are absent from the result.
+func (r *PostgresLikeRepo) GetLikedTargetIDs(ctx context.Context, userID uuid.UUID, targetType string, ids []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	if userID == uuid.Nil {
+		r.logger.Error("GetLikedTargetIDs failed: empty user ID")
+		return nil, commonErrors.ErrInvalidArgument
+	}
+
+	liked := make(map[uuid.UUID]uuid.UUID)
+	if len(ids) == 0 {
+		return liked, nil
+	}
+
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		strIDs[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectLikedTargetsQuery, userID, targetType, pq.Array(strIDs))
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("GetLikedTargetIDs failed")
+		return nil, fmt.Errorf("get liked targets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var targetID, likeID uuid.UUID
+		if err := rows.Scan(&targetID, &likeID); err != nil {
+			r.logger.WithError(err).Error("GetLikedTargetIDs failed: scan like")
+			return nil, fmt.Errorf("scan liked target: %w", err)
+		}
+		liked[targetID] = likeID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate liked targets: %w", err)
+	}
+
+	return liked, nil
+}
+
+// CountReactionsByTarget returns the number of active likes on a target per reaction type.
+func (r *PostgresLikeRepo) CountReactionsByTarget(ctx context.Context, t model.Target) (map[string]int64, error) {
+	if t.Type == "" || t.ID == uuid.Nil {
+		r.logger.Error("CountReactionsByTarget failed: empty target")
+		return nil, commonErrors.ErrInvalidArgument
+	}
+
+	rows, err := r.db.QueryContext(ctx, countReactionsQuery, t.Type, t.ID)
+	if err != nil {
+		r.logger.WithError(err).WithField("target", t.String()).Error("CountReactionsByTarget failed")
+		return nil, fmt.Errorf("count reactions by target: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var reaction string
+		var count int64
+		if err := rows.Scan(&reaction, &count); err != nil {
+			r.logger.WithError(err).Error("CountReactionsByTarget failed: scan count")
+			return nil, fmt.Errorf("scan reaction count: %w", err)
+		}
+		counts[reaction] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reaction counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// CountCreatedSince counts active likes on targets of one type created since the given time,
+// grouped per target into buckets of the given width.
+func (r *PostgresLikeRepo) CountCreatedSince(ctx context.Context, targetType string, since time.Time, bucket time.Duration) ([]model.LikeBucket, error) {
+	if bucket < time.Second {
+		r.logger.WithField("bucket", bucket).Error("CountCreatedSince failed: bucket too small")
+		return nil, commonErrors.ErrInvalidArgument
+	}
+
+	rows, err := r.db.QueryContext(ctx, countCreatedSinceQuery, targetType, since.UTC(), int64(bucket/time.Second))
+	if err != nil {
+		r.logger.WithError(err).WithField("target_type", targetType).Error("CountCreatedSince failed")
+		return nil, fmt.Errorf("count likes created since: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []model.LikeBucket
+	for rows.Next() {
+		var b model.LikeBucket
+		if err := rows.Scan(&b.TargetID, &b.Start, &b.Count); err != nil {
+			r.logger.WithError(err).Error("CountCreatedSince failed: scan bucket")
+			return nil, fmt.Errorf("scan like bucket: %w", err)
+		}
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate like buckets: %w", err)
+	}
+
+	return buckets, nil
+}
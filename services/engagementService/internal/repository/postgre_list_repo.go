@@ -0,0 +1,504 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"errors"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// ListRepo defines the operations on user lists, their members and their followers.
+type ListRepo interface {
+	Create(ctx context.Context, l *model.UserList) error
+	EnsureCloseFriends(ctx context.Context, ownerID uuid.UUID) (*model.UserList, error)
+	Get(ctx context.Context, listID uuid.UUID) (*model.UserList, error)
+	Update(ctx context.Context, l *model.UserList) error
+	Delete(ctx context.Context, l *model.UserList) error
+
+	// includePrivate is only set when the owner looks at their own lists
+	ListByOwner(ctx context.Context, ownerID uuid.UUID, includePrivate bool, p pagination.Params) ([]model.UserList, error)
+	ListFollowed(ctx context.Context, followerID uuid.UUID, p pagination.Params) ([]model.UserList, error)
+
+	AddMember(ctx context.Context, l *model.UserList, memberID uuid.UUID) (bool, error)
+	RemoveMember(ctx context.Context, l *model.UserList, memberID uuid.UUID) error
+	// viewerID hides members in a block with the viewer; uuid.Nil returns everyone
+	GetMembers(ctx context.Context, listID, viewerID uuid.UUID, p pagination.Params) ([]model.ListMember, error)
+	IsCloseFriend(ctx context.Context, ownerID, userID uuid.UUID) (bool, error)
+
+	Follow(ctx context.Context, listID, followerID uuid.UUID) (bool, error)
+	Unfollow(ctx context.Context, listID, followerID uuid.UUID) error
+}
+
+// PostgresListRepo implements ListRepo using a PostgreSQL database.
+type PostgresListRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresListRepo creates a new PostgresListRepo with the given database connection.
+func NewPostgresListRepo(db *sql.DB) *PostgresListRepo {
+	return &PostgresListRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	listColumns = `id, owner_id, name, description, is_private, kind, created_at, updated_at`
+
+	insertListQuery = `
+		INSERT INTO user_lists (id, owner_id, name, description, is_private, kind, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+	`
+
+	insertCloseFriendsQuery = `
+		INSERT INTO user_lists (id, owner_id, name, description, is_private, kind, created_at, updated_at)
+		VALUES ($1, $2, $3, '', TRUE, 'close_friends', $4, $4)
+		ON CONFLICT (owner_id) WHERE kind = 'close_friends' DO NOTHING
+	`
+
+	selectCloseFriendsQuery = `
+		SELECT ` + listColumns + `
+		FROM user_lists
+		WHERE owner_id = $1 AND kind = 'close_friends'
+	`
+
+	selectListQuery = `
+		SELECT ` + listColumns + `
+		FROM user_lists
+		WHERE id = $1
+	`
+
+	updateListQuery = `
+		UPDATE user_lists
+		SET name = $2, description = $3, is_private = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	// A list that goes private loses its followers, who could no longer see it anyway
+	deleteListFollowersQuery = `
+		DELETE FROM user_list_followers WHERE list_id = $1
+	`
+
+	deleteListQuery = `
+		DELETE FROM user_lists WHERE id = $1
+	`
+
+	selectOwnedListsQuery = `
+		SELECT ` + listColumns + `
+		FROM user_lists
+		WHERE owner_id = $1 AND (is_private = FALSE OR $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	selectOwnedListsAfterQuery = `
+		SELECT ` + listColumns + `
+		FROM user_lists
+		WHERE owner_id = $1 AND (is_private = FALSE OR $2) AND (created_at, id) < ($3, $4)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5
+	`
+
+	selectFollowedListsQuery = `
+		SELECT l.id, l.owner_id, l.name, l.description, l.is_private, l.kind, l.created_at, l.updated_at, f.created_at
+		FROM user_list_followers f
+		JOIN user_lists l ON l.id = f.list_id
+		WHERE f.follower_id = $1
+		ORDER BY f.created_at DESC, f.list_id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	selectFollowedListsAfterQuery = `
+		SELECT l.id, l.owner_id, l.name, l.description, l.is_private, l.kind, l.created_at, l.updated_at, f.created_at
+		FROM user_list_followers f
+		JOIN user_lists l ON l.id = f.list_id
+		WHERE f.follower_id = $1 AND (f.created_at, f.list_id) < ($2, $3)
+		ORDER BY f.created_at DESC, f.list_id DESC
+		LIMIT $4
+	`
+
+	insertListMemberQuery = `
+		INSERT INTO user_list_members (list_id, member_id, added_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (list_id, member_id) DO NOTHING
+	`
+
+	deleteListMemberQuery = `
+		DELETE FROM user_list_members WHERE list_id = $1 AND member_id = $2
+	`
+
+	isCloseFriendQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_lists l
+			JOIN user_list_members m ON m.list_id = l.id
+			WHERE l.owner_id = $1 AND l.kind = 'close_friends' AND m.member_id = $2
+		)
+	`
+
+	insertListFollowerQuery = `
+		INSERT INTO user_list_followers (list_id, follower_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (list_id, follower_id) DO NOTHING
+	`
+
+	deleteListFollowerQuery = `
+		DELETE FROM user_list_followers WHERE list_id = $1 AND follower_id = $2
+	`
+)
+
+// Create stores a new list.
+func (r *PostgresListRepo) Create(ctx context.Context, l *model.UserList) error {
+	if _, err := r.db.ExecContext(ctx, insertListQuery, l.ID, l.OwnerID, l.Name, l.Description, l.IsPrivate, l.Kind, l.CreatedAt); err != nil {
+		r.logger.WithField("owner_id", l.OwnerID.String()).WithError(err).Error("Create list failed")
+		return fmt.Errorf("insert list: %w", err)
+	}
+	l.UpdatedAt = l.CreatedAt
+	return nil
+}
+
+// EnsureCloseFriends returns the owner's close friends list, creating it on first use.
+func (r *PostgresListRepo) EnsureCloseFriends(ctx context.Context, ownerID uuid.UUID) (*model.UserList, error) {
+	if _, err := r.db.ExecContext(ctx, insertCloseFriendsQuery, uuid.New(), ownerID, model.CloseFriendsListName, time.Now().UTC()); err != nil {
+		r.logger.WithField("owner_id", ownerID.String()).WithError(err).Error("EnsureCloseFriends failed")
+		return nil, fmt.Errorf("create close friends list: %w", err)
+	}
+
+	l, err := scanList(r.db.QueryRowContext(ctx, selectCloseFriendsQuery, ownerID))
+	if err != nil {
+		return nil, fmt.Errorf("get close friends list: %w", err)
+	}
+	return l, nil
+}
+
+// Get returns a list by ID, or ErrNotFound.
+func (r *PostgresListRepo) Get(ctx context.Context, listID uuid.UUID) (*model.UserList, error) {
+	l, err := scanList(r.db.QueryRowContext(ctx, selectListQuery, listID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, commonErrors.ErrNotFound
+		}
+		r.logger.WithField("list_id", listID.String()).WithError(err).Error("Get list failed")
+		return nil, fmt.Errorf("get list: %w", err)
+	}
+	return l, nil
+}
+
+// Update saves the list's name, description and privacy. Making it private drops its followers.
+func (r *PostgresListRepo) Update(ctx context.Context, l *model.UserList) error {
+	l.UpdatedAt = time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, updateListQuery, l.ID, l.Name, l.Description, l.IsPrivate, l.UpdatedAt)
+	if err != nil {
+		r.logger.WithField("list_id", l.ID.String()).WithError(err).Error("Update list failed")
+		return fmt.Errorf("update list: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update list: %w", err)
+	}
+	if updated == 0 {
+		return commonErrors.ErrNotFound
+	}
+
+	if l.IsPrivate {
+		if _, err := tx.ExecContext(ctx, deleteListFollowersQuery, l.ID); err != nil {
+			return fmt.Errorf("drop list followers: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit list update: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the list with its members and followers.
+func (r *PostgresListRepo) Delete(ctx context.Context, l *model.UserList) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, deleteListQuery, l.ID)
+	if err != nil {
+		r.logger.WithField("list_id", l.ID.String()).WithError(err).Error("Delete list failed")
+		return fmt.Errorf("delete list: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete list: %w", err)
+	}
+	if deleted == 0 {
+		return commonErrors.ErrNotFound
+	}
+
+	err = enqueueOutbox(ctx, tx, events.TopicListDeleted, events.ListDeletedPayload{
+		ListID:    l.ID,
+		OwnerID:   l.OwnerID,
+		DeletedAt: time.Now().UTC().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit list delete: %w", err)
+	}
+	return nil
+}
+
+// ListByOwner returns the owner's lists, newest first.
+func (r *PostgresListRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID, includePrivate bool, p pagination.Params) ([]model.UserList, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if p.After != nil {
+		rows, err = r.db.QueryContext(ctx, selectOwnedListsAfterQuery, ownerID, includePrivate, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		rows, err = r.db.QueryContext(ctx, selectOwnedListsQuery, ownerID, includePrivate, p.Limit, p.Offset)
+	}
+	if err != nil {
+		r.logger.WithField("owner_id", ownerID.String()).WithError(err).Error("ListByOwner failed")
+		return nil, fmt.Errorf("list owned lists: %w", err)
+	}
+	defer rows.Close()
+
+	var lists []model.UserList
+	for rows.Next() {
+		l, err := scanList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan list: %w", err)
+		}
+		lists = append(lists, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lists: %w", err)
+	}
+	return lists, nil
+}
+
+// ListFollowed returns the lists the user follows, most recently followed first.
+func (r *PostgresListRepo) ListFollowed(ctx context.Context, followerID uuid.UUID, p pagination.Params) ([]model.UserList, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if p.After != nil {
+		rows, err = r.db.QueryContext(ctx, selectFollowedListsAfterQuery, followerID, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		rows, err = r.db.QueryContext(ctx, selectFollowedListsQuery, followerID, p.Limit, p.Offset)
+	}
+	if err != nil {
+		r.logger.WithField("follower_id", followerID.String()).WithError(err).Error("ListFollowed failed")
+		return nil, fmt.Errorf("list followed lists: %w", err)
+	}
+	defer rows.Close()
+
+	var lists []model.UserList
+	for rows.Next() {
+		var l model.UserList
+		var followedAt time.Time
+		if err := rows.Scan(&l.ID, &l.OwnerID, &l.Name, &l.Description, &l.IsPrivate, &l.Kind,
+			&l.CreatedAt, &l.UpdatedAt, &followedAt); err != nil {
+			return nil, fmt.Errorf("scan list: %w", err)
+		}
+		l.FollowedAt = &followedAt
+		lists = append(lists, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lists: %w", err)
+	}
+	return lists, nil
+}
+
+// AddMember adds memberID to the list and announces it. Returns false if they already were a member.
+func (r *PostgresListRepo) AddMember(ctx context.Context, l *model.UserList, memberID uuid.UUID) (bool, error) {
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, insertListMemberQuery, l.ID, memberID, now)
+	if err != nil {
+		r.logger.WithField("list_id", l.ID.String()).WithError(err).Error("AddMember failed")
+		return false, fmt.Errorf("insert list member: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("insert list member: %w", err)
+	}
+	if inserted == 0 {
+		return false, nil
+	}
+
+	err = enqueueOutbox(ctx, tx, events.TopicListMemberAdded, events.ListMemberAddedPayload{
+		ListID:   l.ID,
+		OwnerID:  l.OwnerID,
+		MemberID: memberID,
+		Kind:     l.Kind,
+		AddedAt:  now.Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit list member: %w", err)
+	}
+	return true, nil
+}
+
+// RemoveMember removes memberID from the list and announces it. Returns ErrNotFound if they weren't a member.
+func (r *PostgresListRepo) RemoveMember(ctx context.Context, l *model.UserList, memberID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, deleteListMemberQuery, l.ID, memberID)
+	if err != nil {
+		r.logger.WithField("list_id", l.ID.String()).WithError(err).Error("RemoveMember failed")
+		return fmt.Errorf("delete list member: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete list member: %w", err)
+	}
+	if deleted == 0 {
+		return commonErrors.ErrNotFound
+	}
+
+	err = enqueueOutbox(ctx, tx, events.TopicListMemberRemoved, events.ListMemberRemovedPayload{
+		ListID:    l.ID,
+		OwnerID:   l.OwnerID,
+		MemberID:  memberID,
+		Kind:      l.Kind,
+		RemovedAt: time.Now().UTC().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit list member removal: %w", err)
+	}
+	return nil
+}
+
+// GetMembers returns the list's members, most recently added first.
+func (r *PostgresListRepo) GetMembers(ctx context.Context, listID, viewerID uuid.UUID, p pagination.Params) ([]model.ListMember, error) {
+	filter := "list_id = $1"
+	args := []interface{}{listID}
+	if viewerID != uuid.Nil {
+		args = append(args, viewerID)
+		filter += " AND " + notBlockedFilter("user_list_members.member_id", len(args))
+	}
+
+	var querySQL string
+	n := len(args)
+	if p.After != nil {
+		querySQL = fmt.Sprintf(`
+			SELECT list_id, member_id, added_at
+			FROM user_list_members
+			WHERE %s AND (added_at, member_id) < ($%d, $%d)
+			ORDER BY added_at DESC, member_id DESC
+			LIMIT $%d`, filter, n+1, n+2, n+3)
+		args = append(args, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		querySQL = fmt.Sprintf(`
+			SELECT list_id, member_id, added_at
+			FROM user_list_members
+			WHERE %s
+			ORDER BY added_at DESC, member_id DESC
+			LIMIT $%d OFFSET $%d`, filter, n+1, n+2)
+		args = append(args, p.Limit, p.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		r.logger.WithField("list_id", listID.String()).WithError(err).Error("GetMembers failed")
+		return nil, fmt.Errorf("list members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []model.ListMember
+	for rows.Next() {
+		var m model.ListMember
+		if err := rows.Scan(&m.ListID, &m.MemberID, &m.AddedAt); err != nil {
+			return nil, fmt.Errorf("scan list member: %w", err)
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate list members: %w", err)
+	}
+	return members, nil
+}
+
+// IsCloseFriend reports whether userID is on ownerID's close friends list.
+func (r *PostgresListRepo) IsCloseFriend(ctx context.Context, ownerID, userID uuid.UUID) (bool, error) {
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, isCloseFriendQuery, ownerID, userID).Scan(&ok); err != nil {
+		r.logger.WithField("owner_id", ownerID.String()).WithError(err).Error("IsCloseFriend failed")
+		return false, fmt.Errorf("check close friend: %w", err)
+	}
+	return ok, nil
+}
+
+// Follow subscribes the user to the list. Returns false if they already followed it.
+func (r *PostgresListRepo) Follow(ctx context.Context, listID, followerID uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, insertListFollowerQuery, listID, followerID, time.Now().UTC())
+	if err != nil {
+		r.logger.WithField("list_id", listID.String()).WithError(err).Error("Follow list failed")
+		return false, fmt.Errorf("follow list: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("follow list: %w", err)
+	}
+	return inserted > 0, nil
+}
+
+// Unfollow unsubscribes the user from the list. Returns ErrNotFound if they didn't follow it.
+func (r *PostgresListRepo) Unfollow(ctx context.Context, listID, followerID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, deleteListFollowerQuery, listID, followerID)
+	if err != nil {
+		r.logger.WithField("list_id", listID.String()).WithError(err).Error("Unfollow list failed")
+		return fmt.Errorf("unfollow list: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unfollow list: %w", err)
+	}
+	if deleted == 0 {
+		return commonErrors.ErrNotFound
+	}
+	return nil
+}
+
+func scanList(row rowScanner) (*model.UserList, error) {
+	l := &model.UserList{}
+	if err := row.Scan(&l.ID, &l.OwnerID, &l.Name, &l.Description, &l.IsPrivate, &l.Kind, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// MuteRepo defines the operations on mutes. Expired mutes are treated as absent everywhere.
+type MuteRepo interface {
+	Upsert(ctx context.Context, m *model.Mute) error
+	Delete(ctx context.Context, muterID, mutedID uuid.UUID) error
+	List(ctx context.Context, muterID uuid.UUID, p pagination.Params) ([]model.Mute, error)
+	GetMutedIDs(ctx context.Context, muterID uuid.UUID) ([]uuid.UUID, error)
+	FilterMuted(ctx context.Context, muterID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error)
+	DeleteExpired(ctx context.Context, limit int) ([]model.Mute, error)
+}
+
+// PostgresMuteRepo implements MuteRepo using a PostgreSQL database.
+type PostgresMuteRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresMuteRepo creates a new PostgresMuteRepo with the given database connection.
+func NewPostgresMuteRepo(db *sql.DB) *PostgresMuteRepo {
+	return &PostgresMuteRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	// upsertMuteQuery restarts an existing mute, so muting again replaces its expiry
+	upsertMuteQuery = `
+		INSERT INTO mutes (muter_id, muted_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (muter_id, muted_id) DO UPDATE
+			SET created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+	`
+
+	deleteMuteQuery = `
+		DELETE FROM mutes
+		WHERE muter_id = $1 AND muted_id = $2 AND (expires_at IS NULL OR expires_at > $3)
+	`
+
+	selectMutesQuery = `
+		SELECT muter_id, muted_id, created_at, expires_at
+		FROM mutes
+		WHERE muter_id = $1 AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY created_at DESC, muted_id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	selectMutesAfterQuery = `
+		SELECT muter_id, muted_id, created_at, expires_at
+		FROM mutes
+		WHERE muter_id = $1 AND (expires_at IS NULL OR expires_at > $2) AND (created_at, muted_id) < ($3, $4)
+		ORDER BY created_at DESC, muted_id DESC
+		LIMIT $5
+	`
+
+	selectMutedIDsQuery = `
+		SELECT muted_id
+		FROM mutes
+		WHERE muter_id = $1 AND (expires_at IS NULL OR expires_at > $2)
+	`
+
+	filterMutedQuery = `
+		SELECT muted_id
+		FROM mutes
+		WHERE muter_id = $1 AND muted_id = ANY($2::uuid[]) AND (expires_at IS NULL OR expires_at > $3)
+	`
+
+	deleteExpiredMutesQuery = `
+		DELETE FROM mutes
+		WHERE (muter_id, muted_id) IN (
+			SELECT muter_id, muted_id
+			FROM mutes
+			WHERE expires_at <= $1
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING muter_id, muted_id, created_at, expires_at
+	`
+)
+
+// Upsert mutes m.MutedID for m.MuterID, replacing any earlier mute of the same user.
+func (r *PostgresMuteRepo) Upsert(ctx context.Context, m *model.Mute) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, upsertMuteQuery, m.MuterID, m.MutedID, m.CreatedAt, m.ExpiresAt); err != nil {
+		r.logger.WithField("muter_id", m.MuterID.String()).WithError(err).Error("Upsert mute failed")
+		return fmt.Errorf("upsert mute: %w", err)
+	}
+
+	if err := enqueueOutbox(ctx, tx, events.TopicMuteChanged, muteChanged(m, true, m.CreatedAt)); err != nil {
+		return err
+	}
+	if err := appendRelationshipEvent(ctx, tx, m.MuterID, m.MutedID, model.RelationshipMute, m.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit mute: %w", err)
+	}
+	return nil
+}
+
+// Delete lifts a mute. Returns ErrNotFound if there is no active one.
+func (r *PostgresMuteRepo) Delete(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, deleteMuteQuery, muterID, mutedID, now)
+	if err != nil {
+		r.logger.WithField("muter_id", muterID.String()).WithError(err).Error("Delete mute failed")
+		return fmt.Errorf("delete mute: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete mute: %w", err)
+	}
+	if deleted == 0 {
+		return commonErrors.ErrNotFound
+	}
+
+	m := &model.Mute{MuterID: muterID, MutedID: mutedID}
+	if err := enqueueOutbox(ctx, tx, events.TopicMuteChanged, muteChanged(m, false, now)); err != nil {
+		return err
+	}
+	if err := appendRelationshipEvent(ctx, tx, muterID, mutedID, model.RelationshipUnmute, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit unmute: %w", err)
+	}
+	return nil
+}
+
+// List returns the user's active mutes, most recent first.
+func (r *PostgresMuteRepo) List(ctx context.Context, muterID uuid.UUID, p pagination.Params) ([]model.Mute, error) {
+	now := time.Now().UTC()
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if p.After != nil {
+		rows, err = r.db.QueryContext(ctx, selectMutesAfterQuery, muterID, now, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		rows, err = r.db.QueryContext(ctx, selectMutesQuery, muterID, now, p.Limit, p.Offset)
+	}
+	if err != nil {
+		r.logger.WithField("muter_id", muterID.String()).WithError(err).Error("List mutes failed")
+		return nil, fmt.Errorf("list mutes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMutes(rows)
+}
+
+// GetMutedIDs returns everyone the user currently mutes.
+func (r *PostgresMuteRepo) GetMutedIDs(ctx context.Context, muterID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, selectMutedIDsQuery, muterID, time.Now().UTC())
+	if err != nil {
+		r.logger.WithField("muter_id", muterID.String()).WithError(err).Error("GetMutedIDs failed")
+		return nil, fmt.Errorf("get muted ids: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan muted id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate muted ids: %w", err)
+	}
+	return ids, nil
+}
+
+// FilterMuted reports which of ids the user currently mutes. Unmuted IDs are absent from the result.
+func (r *PostgresMuteRepo) FilterMuted(ctx context.Context, muterID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	muted := make(map[uuid.UUID]bool)
+	if len(ids) == 0 {
+		return muted, nil
+	}
+
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		strIDs[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(ctx, filterMutedQuery, muterID, pq.Array(strIDs), time.Now().UTC())
+	if err != nil {
+		r.logger.WithField("muter_id", muterID.String()).WithError(err).Error("FilterMuted failed")
+		return nil, fmt.Errorf("filter muted: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan muted id: %w", err)
+		}
+		muted[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate muted ids: %w", err)
+	}
+	return muted, nil
+}
+
+// DeleteExpired removes up to limit expired mutes and announces each one as lifted.
+func (r *PostgresMuteRepo) DeleteExpired(ctx context.Context, limit int) ([]model.Mute, error) {
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, deleteExpiredMutesQuery, now, limit)
+	if err != nil {
+		r.logger.WithError(err).Error("DeleteExpired mutes failed")
+		return nil, fmt.Errorf("delete expired mutes: %w", err)
+	}
+	expired, err := scanMutes(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range expired {
+		if err := enqueueOutbox(ctx, tx, events.TopicMuteChanged, muteChanged(&expired[i], false, now)); err != nil {
+			return nil, err
+		}
+		if err := appendRelationshipEvent(ctx, tx, expired[i].MuterID, expired[i].MutedID, model.RelationshipUnmute, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit expired mutes: %w", err)
+	}
+	return expired, nil
+}
+
+func scanMutes(rows *sql.Rows) ([]model.Mute, error) {
+	var mutes []model.Mute
+	for rows.Next() {
+		var m model.Mute
+		var expiresAt sql.NullTime
+		if err := rows.Scan(&m.MuterID, &m.MutedID, &m.CreatedAt, &expiresAt); err != nil {
+			return nil, fmt.Errorf("scan mute: %w", err)
+		}
+		if expiresAt.Valid {
+			m.ExpiresAt = &expiresAt.Time
+		}
+		mutes = append(mutes, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate mutes: %w", err)
+	}
+	return mutes, nil
+}
+
+func muteChanged(m *model.Mute, muted bool, at time.Time) events.MuteChangedPayload {
+	payload := events.MuteChangedPayload{
+		MuterID:   m.MuterID,
+		MutedID:   m.MutedID,
+		Muted:     muted,
+		ChangedAt: at.Unix(),
+	}
+	if muted && m.ExpiresAt != nil {
+		expiresAt := m.ExpiresAt.Unix()
+		payload.ExpiresAt = &expiresAt
+	}
+	return payload
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"engagementService/internal/model"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"time"
+)
+
+// OutboxRepo defines the operations the relay needs to drain the outbox table.
+type OutboxRepo interface {
+	ClaimPending(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]*model.OutboxMessage, error)
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, cause error, nextAttemptAt time.Time) error
+	PurgePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OutboxWriter enqueues events that don't accompany a change to another table,
+// so there's no transaction for enqueueOutbox to join.
+type OutboxWriter interface {
+	Enqueue(ctx context.Context, eventType string, payload interface{}) error
+}
+
+// PostgresOutboxRepo implements OutboxRepo and OutboxWriter using a PostgreSQL database.
+type PostgresOutboxRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresOutboxRepo creates a new PostgresOutboxRepo with the given database connection.
+func NewPostgresOutboxRepo(db *sql.DB) *PostgresOutboxRepo {
+	return &PostgresOutboxRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	insertOutboxQuery = `
+		INSERT INTO outbox (id, event_type, partition_key, payload, created_at, next_attempt_at, correlation_id)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $5, NULLIF($6, ''))
+	`
+
+	// claimOutboxQuery leases a batch of pending messages by pushing next_attempt_at forward,
+	// so several replicas can run the relay without publishing the same row twice. A message waits
+	// while an older one with its partition key is backing off or leased, so keyed events stay in order.
+	claimOutboxQuery = `
+		UPDATE outbox
+		SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT o.id
+			FROM outbox o
+			WHERE o.published_at IS NULL AND o.attempts < $2 AND o.next_attempt_at <= $3
+				AND (o.partition_key IS NULL OR NOT EXISTS (
+					SELECT 1 FROM outbox prev
+					WHERE prev.partition_key = o.partition_key AND prev.created_at < o.created_at
+						AND prev.published_at IS NULL AND prev.attempts < $2 AND prev.next_attempt_at > $3
+				))
+			ORDER BY o.created_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, partition_key, correlation_id, payload, attempts, last_error, created_at,
+			next_attempt_at, published_at
+	`
+
+	// claimOutboxLock serializes claims across replicas: the key check above only sees committed leases
+	claimOutboxLock = `SELECT pg_advisory_xact_lock(hashtext('outbox_claim'))`
+
+	markOutboxPublishedQuery = `
+		UPDATE outbox SET published_at = $1, last_error = NULL WHERE id = $2
+	`
+
+	markOutboxFailedQuery = `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2 WHERE id = $3
+	`
+
+	purgeOutboxQuery = `
+		DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < $1
+	`
+)
+
+// enqueueOutbox stores an event inside the caller's transaction,
+// so it is committed or rolled back together with the state change.
+// Payloads implementing events.Keyed are published with their partition key,
+// and the correlation ID in ctx, if any, is kept for the event envelope.
+func enqueueOutbox(ctx context.Context, tx *sql.Tx, eventType string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, insertOutboxQuery, uuid.New(), eventType, partitionKey(payload), body, time.Now().UTC(),
+		events.CorrelationID(ctx)); err != nil {
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+	return nil
+}
+
+// Enqueue stores an event on its own.
+func (r *PostgresOutboxRepo) Enqueue(ctx context.Context, eventType string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, insertOutboxQuery, uuid.New(), eventType, partitionKey(payload), body, time.Now().UTC(),
+		events.CorrelationID(ctx)); err != nil {
+		r.logger.WithField("event_type", eventType).WithError(err).Error("Enqueue failed")
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+	return nil
+}
+
+func partitionKey(payload interface{}) string {
+	if k, ok := payload.(events.Keyed); ok {
+		return k.PartitionKey()
+	}
+	return ""
+}
+
+// ClaimPending leases up to limit messages that are due for (re)delivery.
+// Messages that reached maxAttempts are left in the table for manual inspection.
+func (r *PostgresOutboxRepo) ClaimPending(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]*model.OutboxMessage, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, claimOutboxLock); err != nil {
+		r.logger.WithError(err).Error("ClaimPending failed: lock")
+		return nil, fmt.Errorf("lock outbox claim: %w", err)
+	}
+
+	now := time.Now().UTC()
+	rows, err := tx.QueryContext(ctx, claimOutboxQuery, now.Add(lease), maxAttempts, now, limit)
+	if err != nil {
+		r.logger.WithError(err).Error("ClaimPending failed")
+		return nil, fmt.Errorf("claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []*model.OutboxMessage
+	for rows.Next() {
+		m := &model.OutboxMessage{}
+		var key, correlationID, lastError sql.NullString
+		if err := rows.Scan(&m.ID, &m.EventType, &key, &correlationID, &m.Payload, &m.Attempts, &lastError,
+			&m.CreatedAt, &m.NextAttemptAt, &m.PublishedAt); err != nil {
+			r.logger.WithError(err).Error("ClaimPending failed: scan message")
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		m.PartitionKey = key.String
+		m.CorrelationID = correlationID.String
+		if lastError.Valid {
+			m.LastError = &lastError.String
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox messages: %w", err)
+	}
+	rows.Close()
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit outbox claim: %w", err)
+	}
+
+	// UPDATE ... RETURNING gives no ordering guarantee
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+
+	return messages, nil
+}
+
+// MarkPublished records a successful delivery.
+func (r *PostgresOutboxRepo) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, markOutboxPublishedQuery, time.Now().UTC(), id); err != nil {
+		r.logger.WithError(err).WithField("id", id.String()).Error("MarkPublished failed")
+		return fmt.Errorf("mark outbox message published: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery and schedules the next attempt.
+func (r *PostgresOutboxRepo) MarkFailed(ctx context.Context, id uuid.UUID, cause error, nextAttemptAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, markOutboxFailedQuery, cause.Error(), nextAttemptAt.UTC(), id); err != nil {
+		r.logger.WithError(err).WithField("id", id.String()).Error("MarkFailed failed")
+		return fmt.Errorf("mark outbox message failed: %w", err)
+	}
+	return nil
+}
+
+// PurgePublished removes messages that were published before the given time.
+func (r *PostgresOutboxRepo) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, purgeOutboxQuery, before.UTC())
+	if err != nil {
+		r.logger.WithError(err).Error("PurgePublished failed")
+		return 0, fmt.Errorf("purge outbox: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"engagementService/internal/model"
+	"errors"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// PrivacyRepo stores per-user privacy settings.
+type PrivacyRepo interface {
+	Get(ctx context.Context, userID uuid.UUID) (*model.PrivacySettings, error)
+	Upsert(ctx context.Context, s *model.PrivacySettings) error
+}
+
+// PostgresPrivacyRepo implements PrivacyRepo using a PostgreSQL database.
+type PostgresPrivacyRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresPrivacyRepo creates a new PostgresPrivacyRepo with the given database connection.
+func NewPostgresPrivacyRepo(db *sql.DB) *PostgresPrivacyRepo {
+	return &PostgresPrivacyRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	selectPrivacyQuery = `
+		SELECT user_id, is_private, updated_at
+		FROM privacy_settings
+		WHERE user_id = $1
+	`
+
+	upsertPrivacyQuery = `
+		INSERT INTO privacy_settings (user_id, is_private, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+			SET is_private = EXCLUDED.is_private, updated_at = EXCLUDED.updated_at
+	`
+)
+
+// Get returns the user's settings, or the defaults (public) when none were saved.
+func (r *PostgresPrivacyRepo) Get(ctx context.Context, userID uuid.UUID) (*model.PrivacySettings, error) {
+	s := &model.PrivacySettings{}
+	err := r.db.QueryRowContext(ctx, selectPrivacyQuery, userID).Scan(&s.UserID, &s.IsPrivate, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.PrivacySettings{UserID: userID}, nil
+		}
+		r.logger.WithField("user_id", userID.String()).WithError(err).Error("Get privacy settings failed")
+		return nil, fmt.Errorf("get privacy settings: %w", err)
+	}
+	return s, nil
+}
+
+// Upsert saves the user's settings.
+func (r *PostgresPrivacyRepo) Upsert(ctx context.Context, s *model.PrivacySettings) error {
+	s.UpdatedAt = time.Now().UTC()
+	if _, err := r.db.ExecContext(ctx, upsertPrivacyQuery, s.UserID, s.IsPrivate, s.UpdatedAt); err != nil {
+		r.logger.WithField("user_id", s.UserID.String()).WithError(err).Error("Upsert privacy settings failed")
+		return fmt.Errorf("upsert privacy settings: %w", err)
+	}
+	return nil
+}
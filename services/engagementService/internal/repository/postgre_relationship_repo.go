@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// RelationshipEventRepo reads the relationship_events log. Entries are appended by the repositories
+// that change relationships, in the same transaction, through appendRelationshipEvent.
+type RelationshipEventRepo interface {
+	List(ctx context.Context, q model.RelationshipEventQuery, p pagination.Params) ([]model.RelationshipEvent, error)
+}
+
+// PostgresRelationshipEventRepo implements RelationshipEventRepo using a PostgreSQL database.
+type PostgresRelationshipEventRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresRelationshipEventRepo creates a new PostgresRelationshipEventRepo with the given database connection.
+func NewPostgresRelationshipEventRepo(db *sql.DB) *PostgresRelationshipEventRepo {
+	return &PostgresRelationshipEventRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	insertRelationshipEventQuery = `
+		INSERT INTO relationship_events (id, actor_id, target_id, action, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	// An empty $4 matches every action
+	relationshipEventsFilter = `
+		(actor_id = $1 OR target_id = $1)
+		AND created_at >= $2 AND created_at < $3
+		AND (cardinality($4::text[]) = 0 OR action = ANY($4::text[]))
+	`
+
+	selectRelationshipEventsQuery = `
+		SELECT id, actor_id, target_id, action, created_at
+		FROM relationship_events
+		WHERE ` + relationshipEventsFilter + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5 OFFSET $6
+	`
+
+	selectRelationshipEventsAfterQuery = `
+		SELECT id, actor_id, target_id, action, created_at
+		FROM relationship_events
+		WHERE ` + relationshipEventsFilter + ` AND (created_at, id) < ($5, $6)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $7
+	`
+)
+
+// appendRelationshipEvent records that actorID did action to targetID inside the caller's transaction,
+// so the history can't disagree with the state it describes.
+func appendRelationshipEvent(ctx context.Context, tx *sql.Tx, actorID, targetID uuid.UUID, action string, at time.Time) error {
+	if _, err := tx.ExecContext(ctx, insertRelationshipEventQuery, uuid.New(), actorID, targetID, action, at); err != nil {
+		return fmt.Errorf("append relationship event: %w", err)
+	}
+	return nil
+}
+
+// List returns the user's history, newest first.
+func (r *PostgresRelationshipEventRepo) List(ctx context.Context, q model.RelationshipEventQuery, p pagination.Params) ([]model.RelationshipEvent, error) {
+	actions := pq.Array(q.Actions)
+	if q.Actions == nil {
+		actions = pq.Array([]string{})
+	}
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if p.After != nil {
+		rows, err = r.db.QueryContext(ctx, selectRelationshipEventsAfterQuery, q.UserID, q.From, q.To, actions, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		rows, err = r.db.QueryContext(ctx, selectRelationshipEventsQuery, q.UserID, q.From, q.To, actions, p.Limit, p.Offset)
+	}
+	if err != nil {
+		r.logger.WithField("user_id", q.UserID.String()).WithError(err).Error("List relationship events failed")
+		return nil, fmt.Errorf("list relationship events: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.RelationshipEvent
+	for rows.Next() {
+		var e model.RelationshipEvent
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.TargetID, &e.Action, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan relationship event: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate relationship events: %w", err)
+	}
+	return out, nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql" // Changed from go.mongodb.org/mongo-driver/mongo
 	"engagementService/internal/model"
+	"engagementService/internal/pagination"
 	"errors"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/events"
 	"github.com/google/uuid"
-	"github.com/lib/pq"
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/lib/pq" // PostgreSQL driver
 	"time"
 
 	commonErrors "engagementService/internal/errors" // Import the new errors package
@@ -17,16 +19,28 @@ type SubscriptionRepo interface {
 	EnsureIndexes(ctx context.Context) error
 
 	Create(ctx context.Context, s *model.Subscription) error
+	CreateMany(ctx context.Context, followerID uuid.UUID, followeeIDs []uuid.UUID) (*model.BulkFollowResult, error)
 	Delete(ctx context.Context, followerID, followeeID uuid.UUID) error
 	HardDelete(ctx context.Context, followerID, followeeID uuid.UUID) error
 
 	IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
-
-	GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Subscription, error)
-	GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Subscription, error)
-
-	CountFollowers(ctx context.Context, userID uuid.UUID) (int64, error)
-	CountFollowing(ctx context.Context, userID uuid.UUID) (int64, error)
+	// HasSubscription is IsFollowing that also counts pending follow requests
+	HasSubscription(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+
+	GetPending(ctx context.Context, followeeID uuid.UUID, p pagination.Params) ([]model.Subscription, error)
+	Approve(ctx context.Context, followerID, followeeID uuid.UUID) error
+	ApproveAllPending(ctx context.Context, followeeID uuid.UUID) ([]uuid.UUID, error)
+	DeletePending(ctx context.Context, followerID, followeeID, actorID uuid.UUID, action string) error
+
+	// viewerID hides users in a block with the viewer; uuid.Nil returns everyone
+	GetFollowers(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error)
+	GetFollowing(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error)
+
+	// Subsets of a user's followers, returned as their subscriptions to that user
+	GetMutualFollowers(ctx context.Context, userID, otherID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error)
+	GetFollowersYouKnow(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error)
+	CountFollowersYouKnow(ctx context.Context, userID, viewerID uuid.UUID) (int64, error)
+	GetFriends(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error)
 }
 
 // PostgresSubscriptionRepo replaces MongoSubscriptionRepo
@@ -48,6 +62,7 @@ func (r *PostgresSubscriptionRepo) EnsureIndexes(ctx context.Context) error {
 		id UUID PRIMARY KEY,
 		follower_id UUID NOT NULL,
 		followee_id UUID NOT NULL,
+		approved BOOLEAN NOT NULL DEFAULT TRUE,
 		created_at TIMESTAMP WITH TIME ZONE NOT NULL,
 		deleted_at TIMESTAMP WITH TIME ZONE,
 		UNIQUE (follower_id, followee_id)
@@ -75,6 +90,11 @@ func (r *PostgresSubscriptionRepo) EnsureIndexes(ctx context.Context) error {
 	return nil
 }
 
+// Create inserts a subscription, or restores a soft-deleted one for the same pair with a fresh created_at.
+// Approved is set from the followee's privacy settings as of the insert; unapproved subscriptions are
+// follow requests and emit subscription.requested instead of subscription.created.
+// Returns ErrBlocked if the two users are in a block, ErrDuplicateSubscription if the follower
+// already follows the followee, or ErrFollowRequestPending if they already asked to.
 func (r *PostgresSubscriptionRepo) Create(ctx context.Context, s *model.Subscription) error {
 	if s == nil {
 		return errors.New("subscription is nil")
@@ -86,32 +106,202 @@ func (r *PostgresSubscriptionRepo) Create(ctx context.Context, s *model.Subscrip
 	s.CreatedAt = now
 	s.DeletedAt = nil // Ensure deleted_at is nil for new subscriptions
 
+	// Privacy and blocks are read by the insert itself, so a concurrent block or switch to private
+	// can't slip in between a check and the write.
+	// UNIQUE (follower_id, followee_id) also covers soft-deleted rows, so revive them in place.
+	// No row comes back when an active subscription already exists or the users are in a block.
+	upsertSQL := `
+		INSERT INTO subscriptions (id, follower_id, followee_id, approved, created_at, deleted_at)
+		SELECT $1, $2, c.followee_id, NOT COALESCE(p.is_private, FALSE), $4, NULL
+		FROM (VALUES ($3::uuid)) AS c(followee_id)
+		LEFT JOIN privacy_settings p ON p.user_id = c.followee_id
+		WHERE ` + notBlockedFilter("c.followee_id", 2) + `
+		ON CONFLICT (follower_id, followee_id) DO UPDATE
+			SET deleted_at = NULL, created_at = EXCLUDED.created_at, approved = EXCLUDED.approved
+			WHERE subscriptions.deleted_at IS NOT NULL
+		RETURNING id, approved;`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, upsertSQL, s.ID, s.FollowerID, s.FolloweeID, s.CreatedAt).Scan(&s.ID, &s.Approved)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.skippedError(ctx, tx, s.FollowerID, s.FolloweeID)
+		}
+		return err
+	}
+
+	if s.Approved {
+		if err := adjustFollowStats(ctx, tx, s.FollowerID, s.FolloweeID, 1, now); err != nil {
+			return err
+		}
+		err = enqueueOutbox(ctx, tx, events.TopicSubscriptionCreated, events.SubscriptionCreatedPayload{
+			FollowerID: s.FollowerID,
+			FolloweeID: s.FolloweeID,
+			CreatedAt:  s.CreatedAt.Unix(),
+		})
+	} else {
+		err = enqueueOutbox(ctx, tx, events.TopicSubscriptionRequested, events.SubscriptionRequestedPayload{
+			FollowerID:  s.FollowerID,
+			FolloweeID:  s.FolloweeID,
+			RequestedAt: s.CreatedAt.Unix(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	action := model.RelationshipFollow
+	if !s.Approved {
+		action = model.RelationshipFollowRequest
+	}
+	if err := appendRelationshipEvent(ctx, tx, s.FollowerID, s.FolloweeID, action, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CreateMany follows every followee in one statement, the way Create does for one: private accounts get
+// follow requests, soft-deleted rows are revived, and existing subscriptions or requests are left alone.
+// The follower themselves and accounts in a block with them are skipped. followeeIDs must not repeat.
+func (r *PostgresSubscriptionRepo) CreateMany(ctx context.Context, followerID uuid.UUID, followeeIDs []uuid.UUID) (*model.BulkFollowResult, error) {
+	result := &model.BulkFollowResult{}
+	if len(followeeIDs) == 0 {
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	ids := make([]string, len(followeeIDs))
+	followees := make([]string, len(followeeIDs))
+	for i, id := range followeeIDs {
+		ids[i] = uuid.New().String()
+		followees[i] = id.String()
+	}
+
+	// Same conflict handling as Create: only soft-deleted rows are updated, so active ones return nothing
 	insertSQL := `
-		INSERT INTO subscriptions (id, follower_id, followee_id, created_at, deleted_at)
-		VALUES ($1, $2, $3, $4, $5);`
+		INSERT INTO subscriptions (id, follower_id, followee_id, approved, created_at, deleted_at)
+		SELECT c.id, $1, c.followee_id, NOT COALESCE(p.is_private, FALSE), $4, NULL
+		FROM unnest($2::uuid[], $3::uuid[]) AS c(id, followee_id)
+		LEFT JOIN privacy_settings p ON p.user_id = c.followee_id
+		WHERE c.followee_id <> $1 AND ` + notBlockedFilter("c.followee_id", 1) + `
+		ON CONFLICT (follower_id, followee_id) DO UPDATE
+			SET deleted_at = NULL, created_at = EXCLUDED.created_at, approved = EXCLUDED.approved
+			WHERE subscriptions.deleted_at IS NOT NULL
+		RETURNING followee_id, approved;`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	_, err := r.db.ExecContext(ctx, insertSQL, s.ID, s.FollowerID, s.FolloweeID, s.CreatedAt, s.DeletedAt)
+	rows, err := tx.QueryContext(ctx, insertSQL, followerID, pq.Array(ids), pq.Array(followees), now)
 	if err != nil {
-		// Check for duplicate key error (PostgreSQL-specific error code)
-		// This is a common way to check for unique constraint violations in pq driver
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
-			return commonErrors.ErrDuplicateSubscription // Use commonErrors
+		return nil, err
+	}
+	for rows.Next() {
+		var followeeID uuid.UUID
+		var approved bool
+		if err := rows.Scan(&followeeID, &approved); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if approved {
+			result.Followed = append(result.Followed, followeeID)
+		} else {
+			result.Requested = append(result.Requested, followeeID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result.Followed) > 0 {
+		changes := []followStatsChange{{userID: followerID, following: int64(len(result.Followed))}}
+		for _, followeeID := range result.Followed {
+			changes = append(changes, followStatsChange{userID: followeeID, followers: 1})
+		}
+		if err := applyFollowStats(ctx, tx, changes, now); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, followeeID := range result.Followed {
+		err = enqueueOutbox(ctx, tx, events.TopicSubscriptionCreated, events.SubscriptionCreatedPayload{
+			FollowerID: followerID,
+			FolloweeID: followeeID,
+			CreatedAt:  now.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := appendRelationshipEvent(ctx, tx, followerID, followeeID, model.RelationshipFollow, now); err != nil {
+			return nil, err
+		}
+	}
+	for _, followeeID := range result.Requested {
+		err = enqueueOutbox(ctx, tx, events.TopicSubscriptionRequested, events.SubscriptionRequestedPayload{
+			FollowerID:  followerID,
+			FolloweeID:  followeeID,
+			RequestedAt: now.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := appendRelationshipEvent(ctx, tx, followerID, followeeID, model.RelationshipFollowRequest, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// skippedError tells why Create inserted nothing: an existing subscription, a pending request,
+// or, when neither exists, a block between the pair.
+func (r *PostgresSubscriptionRepo) skippedError(ctx context.Context, tx *sql.Tx, followerID, followeeID uuid.UUID) error {
+	querySQL := `
+		SELECT approved
+		FROM subscriptions
+		WHERE follower_id = $1 AND followee_id = $2 AND deleted_at IS NULL;`
+
+	var approved bool
+	if err := tx.QueryRowContext(ctx, querySQL, followerID, followeeID).Scan(&approved); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return commonErrors.ErrBlocked
 		}
 		return err
 	}
-	return nil
+	if !approved {
+		return commonErrors.ErrFollowRequestPending
+	}
+	return commonErrors.ErrDuplicateSubscription
 }
 
-// Soft delete: set deleted_at if not already deleted
+// Soft delete: set deleted_at if not already deleted. Pending requests are left to DeletePending.
 func (r *PostgresSubscriptionRepo) Delete(ctx context.Context, followerID, followeeID uuid.UUID) error {
 	now := time.Now().UTC()
 	updateSQL := `
 		UPDATE subscriptions
 		SET deleted_at = $1
-		WHERE follower_id = $2 AND followee_id = $3 AND deleted_at IS NULL;`
+		WHERE follower_id = $2 AND followee_id = $3 AND approved AND deleted_at IS NULL;`
 
-	result, err := r.db.ExecContext(ctx, updateSQL, now, followerID, followeeID)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, updateSQL, now, followerID, followeeID)
 	if err != nil {
 		return err
 	}
@@ -123,34 +313,62 @@ func (r *PostgresSubscriptionRepo) Delete(ctx context.Context, followerID, follo
 	if rowsAffected == 0 {
 		return commonErrors.ErrNotFound // Use commonErrors
 	}
-	return nil
+
+	if err := adjustFollowStats(ctx, tx, followerID, followeeID, -1, now); err != nil {
+		return err
+	}
+
+	err = enqueueOutbox(ctx, tx, events.TopicSubscriptionDeleted, events.SubscriptionDeletedPayload{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		DeletedAt:  now.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := appendRelationshipEvent(ctx, tx, followerID, followeeID, model.RelationshipUnfollow, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
+// HardDelete removes the row for good, taking it off the counters if it was an active subscription.
 func (r *PostgresSubscriptionRepo) HardDelete(ctx context.Context, followerID, followeeID uuid.UUID) error {
 	deleteSQL := `
 		DELETE FROM subscriptions
-		WHERE follower_id = $1 AND followee_id = $2;`
+		WHERE follower_id = $1 AND followee_id = $2
+		RETURNING approved AND deleted_at IS NULL;`
 
-	result, err := r.db.ExecContext(ctx, deleteSQL, followerID, followeeID)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return fmt.Errorf("begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+	var counted bool
+	if err := tx.QueryRowContext(ctx, deleteSQL, followerID, followeeID).Scan(&counted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return commonErrors.ErrNotFound // Use commonErrors
+		}
 		return err
 	}
-	if rowsAffected == 0 {
-		return commonErrors.ErrNotFound // Use commonErrors
+
+	if counted {
+		if err := adjustFollowStats(ctx, tx, followerID, followeeID, -1, time.Now().UTC()); err != nil {
+			return err
+		}
 	}
-	return nil
+
+	return tx.Commit()
 }
 
 func (r *PostgresSubscriptionRepo) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
 	querySQL := `
 		SELECT COUNT(*)
 		FROM subscriptions
-		WHERE follower_id = $1 AND followee_id = $2 AND deleted_at IS NULL;`
+		WHERE follower_id = $1 AND followee_id = $2 AND approved AND deleted_at IS NULL;`
 
 	var count int
 	err := r.db.QueryRowContext(ctx, querySQL, followerID, followeeID).Scan(&count)
@@ -160,15 +378,124 @@ func (r *PostgresSubscriptionRepo) IsFollowing(ctx context.Context, followerID,
 	return count > 0, nil
 }
 
-func (r *PostgresSubscriptionRepo) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Subscription, error) {
+func (r *PostgresSubscriptionRepo) HasSubscription(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
 	querySQL := `
-		SELECT id, follower_id, followee_id, created_at, deleted_at
+		SELECT EXISTS (
+			SELECT 1
+			FROM subscriptions
+			WHERE follower_id = $1 AND followee_id = $2 AND deleted_at IS NULL
+		);`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, querySQL, followerID, followeeID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *PostgresSubscriptionRepo) GetFollowers(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	return r.list(ctx, "followee_id", "follower_id", true, userID, viewerID, p)
+}
+
+func (r *PostgresSubscriptionRepo) GetFollowing(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	return r.list(ctx, "follower_id", "followee_id", true, userID, viewerID, p)
+}
+
+// GetPending returns the follow requests waiting for the followee's answer, newest first.
+func (r *PostgresSubscriptionRepo) GetPending(ctx context.Context, followeeID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	return r.list(ctx, "followee_id", "follower_id", false, followeeID, uuid.Nil, p)
+}
+
+// GetMutualFollowers returns the users who follow both userID and otherID, ordered like userID's followers.
+func (r *PostgresSubscriptionRepo) GetMutualFollowers(ctx context.Context, userID, otherID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	filter := "followee_id = $1 AND approved AND deleted_at IS NULL AND " + followsFilter("subscriptions.follower_id", "$2")
+	return r.query(ctx, filter, []interface{}{userID, otherID}, viewerID, p)
+}
+
+// GetFollowersYouKnow returns userID's followers whom viewerID follows,
+// i.e. the "Followed by A, B and 3 others" line on a profile.
+func (r *PostgresSubscriptionRepo) GetFollowersYouKnow(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	filter := "followee_id = $1 AND approved AND deleted_at IS NULL AND " + followsFilter("$2", "subscriptions.follower_id")
+	return r.query(ctx, filter, []interface{}{userID, viewerID}, viewerID, p)
+}
+
+// CountFollowersYouKnow counts what GetFollowersYouKnow lists.
+func (r *PostgresSubscriptionRepo) CountFollowersYouKnow(ctx context.Context, userID, viewerID uuid.UUID) (int64, error) {
+	querySQL := fmt.Sprintf(`
+		SELECT COUNT(*)
 		FROM subscriptions
-		WHERE followee_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3;`
+		WHERE followee_id = $1 AND approved AND deleted_at IS NULL AND %s AND %s;`,
+		followsFilter("$2", "subscriptions.follower_id"), notBlockedFilter("subscriptions.follower_id", 2))
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, querySQL, userID, viewerID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetFriends returns the users who follow userID and are followed back.
+func (r *PostgresSubscriptionRepo) GetFriends(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	filter := "followee_id = $1 AND approved AND deleted_at IS NULL AND " + followsFilter("$1", "subscriptions.follower_id")
+	return r.query(ctx, filter, []interface{}{userID}, viewerID, p)
+}
+
+// followsFilter is a WHERE condition requiring an approved, active subscription from follower to followee.
+// Both sides are columns or placeholders from this package, never user input.
+func followsFilter(follower, followee string) string {
+	return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM subscriptions f
+			WHERE f.follower_id = %s AND f.followee_id = %s AND f.approved AND f.deleted_at IS NULL
+		)`, follower, followee)
+}
+
+// list returns active subscriptions where column = userID and approved matches, newest first.
+// When viewerID is set, rows whose other side (otherColumn) is in a block with the viewer are skipped.
+// Columns are always our own constants, never user input.
+func (r *PostgresSubscriptionRepo) list(ctx context.Context, column, otherColumn string, approved bool, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	filter := fmt.Sprintf("%s = $1 AND approved = %t AND deleted_at IS NULL", column, approved)
+	args := []interface{}{userID}
+	if viewerID != uuid.Nil {
+		args = append(args, viewerID)
+		filter += " AND " + notBlockedFilter("subscriptions."+otherColumn, len(args))
+	}
+	return r.page(ctx, filter, args, p)
+}
+
+// query runs a follower-list filter (rows keyed by follower_id) with the viewer's block filter added.
+func (r *PostgresSubscriptionRepo) query(ctx context.Context, filter string, args []interface{}, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error) {
+	if viewerID != uuid.Nil {
+		args = append(args, viewerID)
+		filter += " AND " + notBlockedFilter("subscriptions.follower_id", len(args))
+	}
+	return r.page(ctx, filter, args, p)
+}
 
-	rows, err := r.db.QueryContext(ctx, querySQL, userID, limit, offset)
+// page selects one page of subscriptions matching filter, whose placeholders are bound to args.
+func (r *PostgresSubscriptionRepo) page(ctx context.Context, filter string, args []interface{}, p pagination.Params) ([]model.Subscription, error) {
+	var querySQL string
+	n := len(args)
+	if p.After != nil {
+		// Keyset pagination: stable under concurrent follows and cheap on deep pages
+		querySQL = fmt.Sprintf(`
+			SELECT id, follower_id, followee_id, approved, created_at, deleted_at
+			FROM subscriptions
+			WHERE %s AND (created_at, id) < ($%d, $%d)
+			ORDER BY created_at DESC, id DESC
+			LIMIT $%d;`, filter, n+1, n+2, n+3)
+		args = append(args, p.After.CreatedAt, p.After.ID, p.Limit)
+	} else {
+		querySQL = fmt.Sprintf(`
+			SELECT id, follower_id, followee_id, approved, created_at, deleted_at
+			FROM subscriptions
+			WHERE %s
+			ORDER BY created_at DESC, id DESC
+			LIMIT $%d OFFSET $%d;`, filter, n+1, n+2)
+		args = append(args, p.Limit, p.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, querySQL, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +505,7 @@ func (r *PostgresSubscriptionRepo) GetFollowers(ctx context.Context, userID uuid
 	for rows.Next() {
 		var s model.Subscription
 		var deletedAt sql.NullTime // Use sql.NullTime for nullable TIMESTAMP
-		err := rows.Scan(&s.ID, &s.FollowerID, &s.FolloweeID, &s.CreatedAt, &deletedAt)
+		err := rows.Scan(&s.ID, &s.FollowerID, &s.FolloweeID, &s.Approved, &s.CreatedAt, &deletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -195,65 +522,146 @@ func (r *PostgresSubscriptionRepo) GetFollowers(ctx context.Context, userID uuid
 	return out, nil
 }
 
-func (r *PostgresSubscriptionRepo) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Subscription, error) {
-	querySQL := `
-		SELECT id, follower_id, followee_id, created_at, deleted_at
-		FROM subscriptions
-		WHERE follower_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3;`
+// Approve turns a pending follow request into a subscription.
+func (r *PostgresSubscriptionRepo) Approve(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	now := time.Now().UTC()
+	updateSQL := `
+		UPDATE subscriptions
+		SET approved = TRUE
+		WHERE follower_id = $1 AND followee_id = $2 AND NOT approved AND deleted_at IS NULL;`
 
-	rows, err := r.db.QueryContext(ctx, querySQL, userID, limit, offset)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("begin transaction: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	var out []model.Subscription
+	result, err := tx.ExecContext(ctx, updateSQL, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return commonErrors.ErrNotFound
+	}
+
+	if err := adjustFollowStats(ctx, tx, followerID, followeeID, 1, now); err != nil {
+		return err
+	}
+
+	err = enqueueOutbox(ctx, tx, events.TopicSubscriptionApproved, events.SubscriptionApprovedPayload{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		ApprovedAt: now.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The followee is the one approving
+	if err := appendRelationshipEvent(ctx, tx, followeeID, followerID, model.RelationshipApprove, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ApproveAllPending approves every pending request to the followee, e.g. after the account went public.
+// Returns the followers whose requests were approved.
+func (r *PostgresSubscriptionRepo) ApproveAllPending(ctx context.Context, followeeID uuid.UUID) ([]uuid.UUID, error) {
+	now := time.Now().UTC()
+	updateSQL := `
+		UPDATE subscriptions
+		SET approved = TRUE
+		WHERE followee_id = $1 AND NOT approved AND deleted_at IS NULL
+		RETURNING follower_id;`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, updateSQL, followeeID)
+	if err != nil {
+		return nil, err
+	}
+	var followerIDs []uuid.UUID
 	for rows.Next() {
-		var s model.Subscription
-		var deletedAt sql.NullTime
-		err := rows.Scan(&s.ID, &s.FollowerID, &s.FolloweeID, &s.CreatedAt, &deletedAt)
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		followerIDs = append(followerIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, followerID := range followerIDs {
+		if err := adjustFollowStats(ctx, tx, followerID, followeeID, 1, now); err != nil {
+			return nil, err
+		}
+		err = enqueueOutbox(ctx, tx, events.TopicSubscriptionApproved, events.SubscriptionApprovedPayload{
+			FollowerID: followerID,
+			FolloweeID: followeeID,
+			ApprovedAt: now.Unix(),
+		})
 		if err != nil {
 			return nil, err
 		}
-		if deletedAt.Valid {
-			s.DeletedAt = &deletedAt.Time
-		} else {
-			s.DeletedAt = nil
+		if err := appendRelationshipEvent(ctx, tx, followeeID, followerID, model.RelationshipApprove, now); err != nil {
+			return nil, err
 		}
-		out = append(out, s)
 	}
-	if err := rows.Err(); err != nil {
+
+	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return followerIDs, nil
 }
 
-func (r *PostgresSubscriptionRepo) CountFollowers(ctx context.Context, userID uuid.UUID) (int64, error) {
-	querySQL := `
-		SELECT COUNT(*)
-		FROM subscriptions
-		WHERE followee_id = $1 AND deleted_at IS NULL;`
+// DeletePending soft-deletes a pending follow request, whether the followee rejects it or the follower cancels it.
+// actorID is whoever did so and is recorded in the relationship log along with action.
+func (r *PostgresSubscriptionRepo) DeletePending(ctx context.Context, followerID, followeeID, actorID uuid.UUID, action string) error {
+	now := time.Now().UTC()
+	updateSQL := `
+		UPDATE subscriptions
+		SET deleted_at = $1
+		WHERE follower_id = $2 AND followee_id = $3 AND NOT approved AND deleted_at IS NULL;`
 
-	var count int64
-	err := r.db.QueryRowContext(ctx, querySQL, userID).Scan(&count)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, err
+		return fmt.Errorf("begin transaction: %w", err)
 	}
-	return count, nil
-}
+	defer tx.Rollback()
 
-func (r *PostgresSubscriptionRepo) CountFollowing(ctx context.Context, userID uuid.UUID) (int64, error) {
-	querySQL := `
-		SELECT COUNT(*)
-		FROM subscriptions
-		WHERE follower_id = $1 AND deleted_at IS NULL;`
+	result, err := tx.ExecContext(ctx, updateSQL, now, followerID, followeeID)
+	if err != nil {
+		return err
+	}
 
-	var count int64
-	err := r.db.QueryRowContext(ctx, querySQL, userID).Scan(&count)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return 0, err
+		return err
 	}
-	return count, nil
+	if rowsAffected == 0 {
+		return commonErrors.ErrNotFound
+	}
+
+	target := followeeID
+	if actorID == followeeID {
+		target = followerID
+	}
+	if err := appendRelationshipEvent(ctx, tx, actorID, target, action, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"engagementService/internal/model"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// SuggestionRepo reads follow suggestion candidates from the subscriptions graph.
+type SuggestionRepo interface {
+	FriendsOfFriends(ctx context.Context, userID uuid.UUID, limit int) ([]model.SuggestionCandidate, error)
+	Popular(ctx context.Context, limit int) ([]model.SuggestionCandidate, error)
+	RecentlyActive(ctx context.Context, since time.Time, limit int) ([]model.SuggestionCandidate, error)
+	FilterExcluded(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error)
+	Dismiss(ctx context.Context, userID, dismissedID uuid.UUID) error
+}
+
+// PostgresSuggestionRepo implements SuggestionRepo using a PostgreSQL database.
+type PostgresSuggestionRepo struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewPostgresSuggestionRepo creates a new PostgresSuggestionRepo with the given database connection.
+func NewPostgresSuggestionRepo(db *sql.DB) *PostgresSuggestionRepo {
+	return &PostgresSuggestionRepo{db: db, logger: logging.GetLogger()}
+}
+
+const (
+	// suggestionExclusion drops the user, accounts they follow or asked to follow, blocks in either direction
+	// and dismissed suggestions. $1 is the user, candidate is a column name.
+	suggestionExclusion = `
+			%[1]s <> $1
+			AND NOT EXISTS (
+				SELECT 1 FROM subscriptions x
+				WHERE x.follower_id = $1 AND x.followee_id = %[1]s AND x.deleted_at IS NULL
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM blocks b
+				WHERE (b.blocker_id = $1 AND b.blocked_id = %[1]s) OR (b.blocker_id = %[1]s AND b.blocked_id = $1)
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM suggestion_dismissals d
+				WHERE d.user_id = $1 AND d.dismissed_id = %[1]s
+			)`
+
+	// friendsOfFriendsQuery counts, for every account the user's followees follow, how many of them do
+	friendsOfFriendsQuery = `
+		SELECT s2.followee_id, COUNT(*) AS mutual
+		FROM subscriptions s1
+		JOIN subscriptions s2 ON s2.follower_id = s1.followee_id AND s2.approved AND s2.deleted_at IS NULL
+		WHERE s1.follower_id = $1 AND s1.approved AND s1.deleted_at IS NULL AND %s
+		GROUP BY s2.followee_id
+		ORDER BY mutual DESC, s2.followee_id
+		LIMIT $2
+	`
+
+	popularQuery = `
+		SELECT followee_id, COUNT(*) AS followers
+		FROM subscriptions
+		WHERE approved AND deleted_at IS NULL
+		GROUP BY followee_id
+		ORDER BY followers DESC, followee_id
+		LIMIT $1
+	`
+
+	recentlyActiveQuery = `
+		SELECT follower_id, COUNT(*) AS follows
+		FROM subscriptions
+		WHERE approved AND deleted_at IS NULL AND created_at >= $1
+		GROUP BY follower_id
+		ORDER BY follows DESC, follower_id
+		LIMIT $2
+	`
+
+	filterExcludedQuery = `
+		SELECT c.id
+		FROM unnest($2::uuid[]) AS c(id)
+		WHERE NOT (%s)
+	`
+
+	insertDismissalQuery = `
+		INSERT INTO suggestion_dismissals (user_id, dismissed_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, dismissed_id) DO NOTHING
+	`
+)
+
+// FriendsOfFriends returns accounts followed by the user's followees, ranked by how many followees follow them.
+func (r *PostgresSuggestionRepo) FriendsOfFriends(ctx context.Context, userID uuid.UUID, limit int) ([]model.SuggestionCandidate, error) {
+	querySQL := fmt.Sprintf(friendsOfFriendsQuery, fmt.Sprintf(suggestionExclusion, "s2.followee_id"))
+	rows, err := r.db.QueryContext(ctx, querySQL, userID, limit)
+	if err != nil {
+		r.logger.WithField("user_id", userID.String()).WithError(err).Error("FriendsOfFriends failed")
+		return nil, fmt.Errorf("friends of friends: %w", err)
+	}
+	defer rows.Close()
+
+	return scanCandidates(rows)
+}
+
+// Popular returns the accounts with the most followers. Exclusions are left to FilterExcluded,
+// so one result serves every user.
+func (r *PostgresSuggestionRepo) Popular(ctx context.Context, limit int) ([]model.SuggestionCandidate, error) {
+	rows, err := r.db.QueryContext(ctx, popularQuery, limit)
+	if err != nil {
+		r.logger.WithError(err).Error("Popular failed")
+		return nil, fmt.Errorf("popular accounts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanCandidates(rows)
+}
+
+// RecentlyActive returns the accounts that followed the most people since the given time.
+func (r *PostgresSuggestionRepo) RecentlyActive(ctx context.Context, since time.Time, limit int) ([]model.SuggestionCandidate, error) {
+	rows, err := r.db.QueryContext(ctx, recentlyActiveQuery, since, limit)
+	if err != nil {
+		r.logger.WithError(err).Error("RecentlyActive failed")
+		return nil, fmt.Errorf("recently active accounts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanCandidates(rows)
+}
+
+// FilterExcluded reports which of ids must not be suggested to the user. Allowed IDs are absent from the result.
+func (r *PostgresSuggestionRepo) FilterExcluded(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	excluded := make(map[uuid.UUID]bool)
+	if len(ids) == 0 {
+		return excluded, nil
+	}
+
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		strIDs[i] = id.String()
+	}
+
+	querySQL := fmt.Sprintf(filterExcludedQuery, fmt.Sprintf(suggestionExclusion, "c.id"))
+	rows, err := r.db.QueryContext(ctx, querySQL, userID, pq.Array(strIDs))
+	if err != nil {
+		r.logger.WithField("user_id", userID.String()).WithError(err).Error("FilterExcluded failed")
+		return nil, fmt.Errorf("filter excluded suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan excluded id: %w", err)
+		}
+		excluded[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate excluded ids: %w", err)
+	}
+	return excluded, nil
+}
+
+// Dismiss stops dismissedID from being suggested to the user. Dismissing twice is a no-op.
+func (r *PostgresSuggestionRepo) Dismiss(ctx context.Context, userID, dismissedID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, insertDismissalQuery, userID, dismissedID, time.Now().UTC()); err != nil {
+		r.logger.WithField("user_id", userID.String()).WithError(err).Error("Dismiss suggestion failed")
+		return fmt.Errorf("dismiss suggestion: %w", err)
+	}
+	return nil
+}
+
+func scanCandidates(rows *sql.Rows) ([]model.SuggestionCandidate, error) {
+	var candidates []model.SuggestionCandidate
+	for rows.Next() {
+		var c model.SuggestionCandidate
+		if err := rows.Scan(&c.UserID, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan suggestion candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate suggestion candidates: %w", err)
+	}
+	return candidates, nil
+}
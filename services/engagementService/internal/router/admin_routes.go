@@ -0,0 +1,17 @@
+package router
+
+import (
+	"engagementService/internal/bootstrap"
+	"engagementService/internal/delivery"
+	"github.com/Sayan80bayev/go-project/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminRoutes(r *gin.Engine, c *bootstrap.Container) {
+	h := delivery.NewAdminHandler(c.SubscriptionService)
+
+	routes := r.Group("api/v1/admin", middleware.AuthMiddleware(c.JWKSUrl), middleware.RequireRole(middleware.RoleAdmin))
+	{
+		routes.GET("/relationships/:userId/events", h.GetRelationshipEvents)
+	}
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"engagementService/internal/bootstrap"
+	"engagementService/internal/delivery"
+	"github.com/Sayan80bayev/go-project/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBlockRoutes(r *gin.Engine, c *bootstrap.Container) {
+	h := delivery.NewBlockHandler(c.BlockService)
+
+	routes := r.Group("api/v1/blocks", middleware.AuthMiddleware(c.JWKSUrl))
+	{
+		routes.GET("", h.GetBlocked)
+		routes.POST("/:userId", h.Block)
+		routes.DELETE("/:userId", h.Unblock)
+	}
+}
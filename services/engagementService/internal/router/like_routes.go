@@ -3,6 +3,7 @@ package router
 import (
 	"engagementService/internal/bootstrap"
 	"engagementService/internal/delivery"
+	"engagementService/internal/model"
 	"github.com/Sayan80bayev/go-project/pkg/middleware"
 	"github.com/gin-gonic/gin"
 )
@@ -11,9 +12,33 @@ func SetupLikeRoutes(r *gin.Engine, c *bootstrap.Container) {
 	h := delivery.NewLikeHandler(c.LikeService)
 	routes := r.Group("api/v1/like", middleware.AuthMiddleware(c.JWKSUrl))
 	{
-		routes.POST("/:postId/like", h.Like)
-		routes.DELETE("/:postId/unlike", h.Unlike)
 		routes.GET("/user/:userId/likes", h.GetUserLikes)
-		routes.GET("/post/:postId/likes", h.GetPostLikes)
+
+		routes.POST("/:targetType/:targetId", h.Like)
+		routes.DELETE("/:targetType/:targetId", h.Unlike)
+		routes.PUT("/:targetType/:targetId/reaction", h.ChangeReaction)
+		routes.GET("/:targetType/:targetId/likes", h.GetTargetLikes)
+		routes.GET("/:targetType/:targetId/count", h.GetLikeCount)
+		routes.GET("/:targetType/:targetId/reactions", h.GetReactions)
+		routes.POST("/:targetType/counts", h.GetLikeCounts)
+		routes.POST("/:targetType/status", h.GetLikeStatuses)
+
+		// Post-only routes from before likes were polymorphic.
+		// Gin allows one wildcard name per path segment, so the post ID arrives as :targetType.
+		routes.POST("/:targetType/like", legacyPostRoute, h.Like)
+		routes.DELETE("/:targetType/unlike", legacyPostRoute, h.Unlike)
+		routes.PUT("/:targetType/reaction", legacyPostRoute, h.ChangeReaction)
+	}
+}
+
+// legacyPostRoute turns /:postId/<action> params into the (:targetType, :targetId) pair the handlers read.
+func legacyPostRoute(c *gin.Context) {
+	postID := c.Param("targetType")
+	for i := range c.Params {
+		if c.Params[i].Key == "targetType" {
+			c.Params[i].Value = model.TargetPost
+		}
 	}
+	c.Params = append(c.Params, gin.Param{Key: "targetId", Value: postID})
+	c.Header("Deprecation", "true")
 }
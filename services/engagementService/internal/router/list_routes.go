@@ -0,0 +1,32 @@
+package router
+
+import (
+	"engagementService/internal/bootstrap"
+	"engagementService/internal/delivery"
+	"github.com/Sayan80bayev/go-project/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupListRoutes(r *gin.Engine, c *bootstrap.Container) {
+	h := delivery.NewListHandler(c.ListService)
+
+	routes := r.Group("api/v1/lists", middleware.AuthMiddleware(c.JWKSUrl))
+	{
+		routes.POST("", h.CreateList)
+		routes.GET("", h.GetMyLists)
+		routes.GET("/followed", h.GetFollowedLists)
+		routes.GET("/close-friends", h.GetCloseFriends)
+		routes.GET("/user/:userId", h.GetUserLists)
+
+		routes.GET("/:listId", h.GetList)
+		routes.PATCH("/:listId", h.UpdateList)
+		routes.DELETE("/:listId", h.DeleteList)
+
+		routes.GET("/:listId/members", h.GetMembers)
+		routes.PUT("/:listId/members/:userId", h.AddMember)
+		routes.DELETE("/:listId/members/:userId", h.RemoveMember)
+
+		routes.POST("/:listId/follow", h.FollowList)
+		routes.DELETE("/:listId/follow", h.UnfollowList)
+	}
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"engagementService/internal/bootstrap"
+	"engagementService/internal/delivery"
+	"github.com/Sayan80bayev/go-project/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupMuteRoutes(r *gin.Engine, c *bootstrap.Container) {
+	h := delivery.NewMuteHandler(c.MuteService)
+
+	routes := r.Group("api/v1/mutes", middleware.AuthMiddleware(c.JWKSUrl))
+	{
+		routes.GET("", h.GetMuted)
+		routes.GET("/ids", h.GetMutedIDs)
+		routes.POST("/:userId", h.Mute)
+		routes.DELETE("/:userId", h.Unmute)
+	}
+}
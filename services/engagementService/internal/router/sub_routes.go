@@ -9,6 +9,7 @@ import (
 
 func SetupSubscriptionRoutes(r *gin.Engine, c *bootstrap.Container) {
 	h := delivery.NewSubscriptionHandler(c.SubscriptionService)
+	ih := delivery.NewFollowImportHandler(c.FollowImportService, c.SubscriptionService)
 
 	routes := r.Group("api/v1/sub", middleware.AuthMiddleware(c.JWKSUrl))
 	{
@@ -16,5 +17,22 @@ func SetupSubscriptionRoutes(r *gin.Engine, c *bootstrap.Container) {
 		routes.DELETE("/:followeeId/unfollow", h.Unfollow)
 		routes.GET("/:userId/followers", h.GetFollowers)
 		routes.GET("/:userId/following", h.GetFollowing)
+		routes.GET("/:userId/followers/known", h.GetFollowersYouKnow)
+		routes.GET("/:userId/mutual/:otherUserId", h.GetMutualFollowers)
+		routes.GET("/:userId/friends", h.GetFriends)
+		routes.GET("/:userId/count", h.Count)
+		routes.POST("/stats", h.BatchStats)
+
+		routes.DELETE("/:followeeId/request", h.CancelRequest)
+		routes.GET("/requests", h.GetPendingRequests)
+		routes.POST("/requests/:followerId/approve", h.ApproveRequest)
+		routes.POST("/requests/:followerId/reject", h.RejectRequest)
+
+		routes.GET("/settings/privacy", h.GetPrivacy)
+		routes.PUT("/settings/privacy", h.UpdatePrivacy)
+
+		routes.POST("/import", ih.Import)
+		routes.GET("/import/:jobId", ih.ImportStatus)
+		routes.GET("/export", ih.Export)
 	}
 }
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"engagementService/internal/bootstrap"
+	"engagementService/internal/delivery"
+	"github.com/Sayan80bayev/go-project/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSuggestionRoutes(r *gin.Engine, c *bootstrap.Container) {
+	h := delivery.NewSuggestionHandler(c.SuggestionService)
+
+	routes := r.Group("api/v1/sub/suggestions", middleware.AuthMiddleware(c.JWKSUrl))
+	{
+		routes.GET("", h.GetSuggestions)
+		routes.POST("/:userId/dismiss", h.DismissSuggestion)
+	}
+}
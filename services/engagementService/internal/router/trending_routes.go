@@ -0,0 +1,17 @@
+package router
+
+import (
+	"engagementService/internal/bootstrap"
+	"engagementService/internal/delivery"
+	"github.com/Sayan80bayev/go-project/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTrendingRoutes(r *gin.Engine, c *bootstrap.Container) {
+	h := delivery.NewTrendingHandler(c.TrendingService)
+
+	routes := r.Group("api/v1/trending", middleware.AuthMiddleware(c.JWKSUrl))
+	{
+		routes.GET("", h.GetTrending)
+	}
+}
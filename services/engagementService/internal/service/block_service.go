@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"engagementService/internal/repository"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// BlockService handles blocking between users.
+// Follows are refused and listings filtered by SubscriptionService and LikeService, which read the same table.
+type BlockService struct {
+	repo   repository.BlockRepo
+	stats  *FollowStatsService
+	logger *logrus.Logger
+}
+
+// NewBlockService creates a new BlockService.
+func NewBlockService(repo repository.BlockRepo, stats *FollowStatsService) *BlockService {
+	return &BlockService{repo: repo, stats: stats, logger: logging.GetLogger()}
+}
+
+// Block blocks blockedID on behalf of blockerID and removes subscriptions between them in both directions.
+// Blocking an already blocked user is a no-op; the boolean result reports whether a block was added.
+func (s *BlockService) Block(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	if blockerID == uuid.Nil || blockedID == uuid.Nil {
+		return false, fmt.Errorf("%w: user IDs cannot be empty", commonErrors.ErrInvalidArgument)
+	}
+	if blockerID == blockedID {
+		return false, fmt.Errorf("%w: cannot block self", commonErrors.ErrInvalidArgument)
+	}
+
+	created, err := s.repo.Block(ctx, blockerID, blockedID)
+	if err != nil {
+		return false, err
+	}
+	if created {
+		s.stats.Invalidate(ctx, blockerID, blockedID)
+		s.logger.WithField("blocker_id", blockerID.String()).Info("User blocked")
+	}
+	return created, nil
+}
+
+// Unblock lifts a block. Returns ErrNotFound if there was none.
+func (s *BlockService) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	return s.repo.Unblock(ctx, blockerID, blockedID)
+}
+
+// List returns one page of the users blockerID has blocked.
+func (s *BlockService) List(ctx context.Context, blockerID uuid.UUID, p pagination.Params) (pagination.Page[model.Block], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.Block]{}, err
+	}
+	blocks, err := s.repo.List(ctx, blockerID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.Block]{}, err
+	}
+	return pagination.NewPage(blocks, p.Limit, blockCursor), nil
+}
+
+func blockCursor(b model.Block) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: b.CreatedAt, ID: b.BlockedID}
+}
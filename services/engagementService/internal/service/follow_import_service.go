@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/repository"
+	"errors"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// followImportLease is how long a running job may go without progress before another replica takes it over
+const followImportLease = 2 * time.Minute
+
+// FollowImportServiceConfig holds the tunables of FollowImportService.
+type FollowImportServiceConfig struct {
+	BatchSize int // accounts followed per transaction
+	MaxSize   int // accounts accepted per import
+}
+
+// FollowImportService follows lists of accounts in bulk, e.g. for users moving in from another platform.
+// Imports are stored as jobs and processed by the follow importer, one batch insert at a time.
+type FollowImportService struct {
+	repo   repository.FollowImportRepo
+	subs   repository.SubscriptionRepo
+	stats  *FollowStatsService
+	limits *RateLimitService
+	cfg    FollowImportServiceConfig
+	logger *logrus.Logger
+}
+
+// NewFollowImportService creates a new FollowImportService.
+func NewFollowImportService(repo repository.FollowImportRepo, subs repository.SubscriptionRepo, stats *FollowStatsService, limits *RateLimitService, cfg FollowImportServiceConfig) *FollowImportService {
+	return &FollowImportService{repo: repo, subs: subs, stats: stats, limits: limits, cfg: cfg, logger: logging.GetLogger()}
+}
+
+// Start queues an import of the given accounts for the user. Repeated IDs are dropped.
+// Returns ErrImportInProgress if the user's previous import hasn't finished yet.
+func (s *FollowImportService) Start(ctx context.Context, userID uuid.UUID, userIDs []uuid.UUID) (*model.FollowImportJob, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user id is required", commonErrors.ErrInvalidArgument)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 || len(ids) > s.cfg.MaxSize {
+		return nil, fmt.Errorf("%w: between 1 and %d user IDs are required", commonErrors.ErrInvalidArgument, s.cfg.MaxSize)
+	}
+
+	now := time.Now().UTC()
+	job := &model.FollowImportJob{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    model.ImportPending,
+		UserIDs:   ids,
+		Total:     len(ids),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	s.logger.WithField("job_id", job.ID.String()).Infof("Follow import queued (%d accounts)", job.Total)
+	return job, nil
+}
+
+// Get returns one of the user's import jobs. Jobs of other users look like they don't exist.
+func (s *FollowImportService) Get(ctx context.Context, jobID, userID uuid.UUID) (*model.FollowImportJob, error) {
+	job, err := s.repo.Get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, commonErrors.ErrNotFound
+	}
+	return job, nil
+}
+
+// ProcessNext claims the next due job and runs it to the end, saving progress after every batch.
+// Returns false if there was nothing to do. Every batch is charged against the user's follow import
+// rate limit; once it runs out the job goes back to pending until the limit allows the next batch.
+// A job that hits an error is marked failed; importing the same list again is safe, since accounts
+// that are already followed are skipped.
+func (s *FollowImportService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.repo.ClaimNext(ctx, followImportLease)
+	if err != nil || job == nil {
+		return false, err
+	}
+	log := s.logger.WithField("job_id", job.ID.String())
+
+	for job.Processed < job.Total {
+		if ctx.Err() != nil {
+			// Left running; another replica picks it up once the lease runs out
+			return true, ctx.Err()
+		}
+
+		end := job.Processed + s.limits.MaxBatch(ActionFollowImport, s.cfg.BatchSize)
+		if end > job.Total {
+			end = job.Total
+		}
+		batch := job.UserIDs[job.Processed:end]
+		if len(batch) == 0 {
+			// Would never make progress; config validation should have caught the batch size
+			return true, s.finish(ctx, job, errors.New("follow import batch is empty"))
+		}
+
+		if err := s.limits.AllowN(ctx, job.UserID, ActionFollowImport, len(batch)); err != nil {
+			var limited *commonErrors.RateLimitError
+			if !errors.As(err, &limited) {
+				return true, s.finish(ctx, job, err)
+			}
+			resumeAt := time.Now().UTC().Add(limited.RetryAfter)
+			job.Status = model.ImportPending
+			job.ResumeAt = &resumeAt
+			job.UpdatedAt = time.Now().UTC()
+			log.Infof("Follow import rate limited, resuming at %s", resumeAt.Format(time.RFC3339))
+			return true, s.repo.Update(ctx, job)
+		}
+
+		result, err := s.subs.CreateMany(ctx, job.UserID, batch)
+		if err != nil {
+			log.WithError(err).Warn("Follow import failed")
+			return true, s.finish(ctx, job, err)
+		}
+
+		job.Processed = end
+		job.Followed += len(result.Followed)
+		job.Requested += len(result.Requested)
+		job.Skipped += len(batch) - len(result.Followed) - len(result.Requested)
+		job.UpdatedAt = time.Now().UTC()
+		if len(result.Followed) > 0 {
+			s.stats.Invalidate(ctx, append(result.Followed, job.UserID)...)
+		}
+		if job.Processed < job.Total {
+			if err := s.repo.Update(ctx, job); err != nil {
+				return true, err
+			}
+		}
+	}
+
+	log.Infof("Follow import done: %d followed, %d requested, %d skipped", job.Followed, job.Requested, job.Skipped)
+	return true, s.finish(ctx, job, nil)
+}
+
+// finish marks the job done, or failed with cause.
+func (s *FollowImportService) finish(ctx context.Context, job *model.FollowImportJob, cause error) error {
+	now := time.Now().UTC()
+	job.Status = model.ImportDone
+	if cause != nil {
+		job.Status = model.ImportFailed
+		msg := cause.Error()
+		job.LastError = &msg
+	}
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	return s.repo.Update(ctx, job)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/repository"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/caching"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	followStatsKeyPrefix = "follow_stats:"
+	followStatsTTL       = 10 * time.Minute
+)
+
+// FollowStatsService serves follower/following counts from user_follow_stats through a Redis cache.
+// Services that change subscriptions call Invalidate for the users involved.
+type FollowStatsService struct {
+	repo   repository.FollowStatsRepo
+	cache  caching.CacheService
+	logger *logrus.Logger
+}
+
+// NewFollowStatsService creates a new FollowStatsService.
+func NewFollowStatsService(repo repository.FollowStatsRepo, cache caching.CacheService) *FollowStatsService {
+	return &FollowStatsService{repo: repo, cache: cache, logger: logging.GetLogger()}
+}
+
+// Get returns the user's counts, reading through the cache.
+func (s *FollowStatsService) Get(ctx context.Context, userID uuid.UUID) (model.FollowStats, error) {
+	stats, err := s.GetMany(ctx, []uuid.UUID{userID})
+	if err != nil {
+		return model.FollowStats{}, err
+	}
+	return stats[userID], nil
+}
+
+// GetMany returns counts for many users, loading cache misses with a single query.
+func (s *FollowStatsService) GetMany(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]model.FollowStats, error) {
+	if len(userIDs) == 0 || len(userIDs) > MaxBulkIDs {
+		return nil, fmt.Errorf("%w: between 1 and %d IDs are required", commonErrors.ErrInvalidArgument, MaxBulkIDs)
+	}
+
+	stats := make(map[uuid.UUID]model.FollowStats, len(userIDs))
+	var missing []uuid.UUID
+	for _, id := range userIDs {
+		if st, ok := s.cached(ctx, id); ok {
+			stats[id] = st
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	if len(missing) == 0 {
+		return stats, nil
+	}
+
+	loaded, err := s.repo.GetMany(ctx, missing)
+	if err != nil {
+		s.logger.WithError(err).Error("GetMany follow stats failed")
+		return nil, err
+	}
+	for id, st := range loaded {
+		stats[id] = st
+		s.store(ctx, st)
+	}
+	return stats, nil
+}
+
+// Invalidate evicts the cached counts of the given users.
+func (s *FollowStatsService) Invalidate(ctx context.Context, userIDs ...uuid.UUID) {
+	for _, id := range userIDs {
+		if err := s.cache.Delete(ctx, followStatsKeyPrefix+id.String()); err != nil {
+			s.logger.WithField("user_id", id.String()).WithError(err).Warn("Failed to evict cached follow stats")
+		}
+	}
+}
+
+// cached is the read side of the cache-aside pattern; any cache failure counts as a miss.
+func (s *FollowStatsService) cached(ctx context.Context, userID uuid.UUID) (model.FollowStats, bool) {
+	val, err := s.cache.Get(ctx, followStatsKeyPrefix+userID.String())
+	if err != nil || val == "" {
+		return model.FollowStats{}, false
+	}
+
+	var st model.FollowStats
+	if err := json.Unmarshal([]byte(val), &st); err != nil {
+		s.logger.WithField("user_id", userID.String()).Warn("Ignoring malformed cached follow stats")
+		return model.FollowStats{}, false
+	}
+	return st, true
+}
+
+func (s *FollowStatsService) store(ctx context.Context, st model.FollowStats) {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return
+	}
+	if err := s.cache.Set(ctx, followStatsKeyPrefix+st.UserID.String(), data, followStatsTTL); err != nil {
+		s.logger.WithField("user_id", st.UserID.String()).WithError(err).Warn("Failed to cache follow stats")
+	}
+}
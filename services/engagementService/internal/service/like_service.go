@@ -2,57 +2,140 @@ package service
 
 import (
 	"context"
+	commonErrors "engagementService/internal/errors"
 	"engagementService/internal/model"
+	"engagementService/internal/pagination"
 	"engagementService/internal/repository"
 	"engagementService/internal/transport/request"
 	"errors"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/caching"
 	"github.com/Sayan80bayev/go-project/pkg/logging"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const (
+	likeCountKeyPrefix = "like_count:"
+	likeCountTTL       = 10 * time.Minute
+	likedKeyPrefix     = "liked:"
+	notLikedMarker     = "-"
+	MaxBulkIDs         = 100
+)
+
+// A changed count is marked stale for staleCountTTL rather than deleted, so that reads which
+// started before the change can't cache the old value; see cacheCount.
+const (
+	staleCountMarker = "stale"
+	staleCountTTL    = 5 * time.Second
+)
+
+// LikeServiceConfig holds the tunables of LikeService.
+type LikeServiceConfig struct {
+	// StatusCacheTTL controls how long per-user "has liked" answers stay in Redis; zero turns that cache off
+	StatusCacheTTL time.Duration
+	// ExtraReactions are accepted in addition to model.BuiltinReactions
+	ExtraReactions []string
+}
+
 // LikeService handles business logic for like-related operations.
 type LikeService struct {
-	repo   repository.LikeRepo
-	logger *logrus.Logger
+	repo      repository.LikeRepo
+	blocks    repository.BlockRepo
+	cache     caching.CacheService
+	trending  *TrendingService
+	limits    *RateLimitService
+	statusTTL time.Duration
+	reactions map[string]struct{}
+	logger    *logrus.Logger
 }
 
-// NewLikeService creates a new LikeService with the given repository and logger.
-func NewLikeService(repo repository.LikeRepo) *LikeService {
-	return &LikeService{repo: repo, logger: logging.GetLogger()}
+// NewLikeService creates a new LikeService with the given repositories and cache.
+// Likes on posts are also fed to trending.
+func NewLikeService(repo repository.LikeRepo, blocks repository.BlockRepo, cache caching.CacheService, trending *TrendingService, limits *RateLimitService, cfg LikeServiceConfig) *LikeService {
+	reactions := make(map[string]struct{}, len(model.BuiltinReactions)+len(cfg.ExtraReactions))
+	for _, r := range model.BuiltinReactions {
+		reactions[r] = struct{}{}
+	}
+	for _, r := range cfg.ExtraReactions {
+		if r = strings.ToLower(strings.TrimSpace(r)); r != "" {
+			reactions[r] = struct{}{}
+		}
+	}
+
+	return &LikeService{
+		repo:      repo,
+		blocks:    blocks,
+		cache:     cache,
+		trending:  trending,
+		limits:    limits,
+		statusTTL: cfg.StatusCacheTTL,
+		reactions: reactions,
+		logger:    logging.GetLogger(),
+	}
 }
 
-// Create adds a new like for a user and post.
-// Returns an error if the request is nil or if user_id or post_id is empty.
-func (s *LikeService) Create(ctx context.Context, r *request.LikeRequest) (*model.Like, error) {
+// Create likes a target on behalf of a user, or changes the reaction of an existing like in place.
+// Liking an already liked target returns the existing like; the boolean result reports whether a like was added.
+// Returns an error if the request is nil, user_id or the target is empty, or the target or reaction type is unknown,
+// and a RateLimitError if the user likes too often.
+func (s *LikeService) Create(ctx context.Context, r *request.LikeRequest) (*model.Like, bool, error) {
 	if r == nil {
 		s.logger.Error("Create like failed: request is nil")
-		return nil, errors.New("request cannot be nil")
+		return nil, false, errors.New("request cannot be nil")
 	}
 	if r.UserID == uuid.Nil {
 		s.logger.Error("Create like failed: empty user ID")
-		return nil, errors.New("user ID cannot be empty")
+		return nil, false, errors.New("user ID cannot be empty")
+	}
+	target := model.Target{Type: r.TargetType, ID: r.TargetID}
+	if err := validateTarget(target); err != nil {
+		s.logger.WithField("target", target.String()).Error("Create like failed: invalid target")
+		return nil, false, err
+	}
+
+	reaction, err := s.normalizeReaction(r.Reaction)
+	if err != nil {
+		s.logger.WithField("reaction", r.Reaction).Error("Create like failed: unknown reaction")
+		return nil, false, err
+	}
+
+	// Only new likes count towards the limit; liking again or changing the reaction is free.
+	statuses, err := s.GetStatuses(ctx, r.UserID, target.Type, []uuid.UUID{target.ID})
+	if err != nil {
+		return nil, false, err
 	}
-	if r.PostID == uuid.Nil {
-		s.logger.Error("Create like failed: empty post ID")
-		return nil, errors.New("post ID cannot be empty")
+	if !statuses[target.ID].Liked {
+		if err := s.limits.Allow(ctx, r.UserID, ActionLike); err != nil {
+			return nil, false, err
+		}
 	}
 
 	like := &model.Like{
-		PostID: r.PostID,
-		UserID: r.UserID,
+		UserID:     r.UserID,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		Reaction:   reaction,
 	}
 
-	createdLike, err := s.repo.Create(ctx, like)
+	createdLike, created, err := s.repo.Create(ctx, like)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"user_id": r.UserID.String(),
-			"post_id": r.PostID.String(),
+			"target":  target.String(),
 		}).WithError(err).Error("Create like failed")
-		return nil, err
+		return nil, false, err
+	}
+	if created {
+		s.invalidateCount(ctx, target)
+		s.storeStatus(ctx, r.UserID, target, createdLike.ID.String())
+		s.recordTrending(ctx, createdLike, 1)
+		s.logger.WithField("id", createdLike.ID.String()).Info("Like created")
 	}
-	s.logger.WithField("id", createdLike.ID.String()).Info("Like created")
-	return createdLike, nil
+	return createdLike, created, nil
 }
 
 // GetByID retrieves a like by its ID.
@@ -71,50 +154,65 @@ func (s *LikeService) GetByID(ctx context.Context, id uuid.UUID) (*model.Like, e
 	return like, nil
 }
 
-// GetByPostID retrieves all likes for a given post ID with pagination.
-// Returns an error if the post ID is empty or no likes are found.
-func (s *LikeService) GetByPostID(ctx context.Context, postID uuid.UUID, limit, offset int) ([]*model.Like, error) {
-	if postID == uuid.Nil {
-		s.logger.Error("GetByPostID failed: empty post ID")
-		return nil, errors.New("post ID cannot be empty")
+// GetByTarget returns one page of likes on a target, newest first, without likes by users in a block with the viewer.
+// Returns an error if the target is invalid or the pagination parameters are invalid.
+func (s *LikeService) GetByTarget(ctx context.Context, t model.Target, viewerID uuid.UUID, p pagination.Params) (pagination.Page[*model.Like], error) {
+	if err := validateTarget(t); err != nil {
+		s.logger.WithField("target", t.String()).Error("GetByTarget failed: invalid target")
+		return pagination.Page[*model.Like]{}, err
 	}
-	if limit <= 0 || offset < 0 {
+	if err := p.Validate(); err != nil {
 		s.logger.WithFields(logrus.Fields{
-			"limit":  limit,
-			"offset": offset,
-		}).Error("GetByPostID failed: invalid pagination parameters")
-		return nil, errors.New("invalid pagination parameters")
+			"limit":  p.Limit,
+			"offset": p.Offset,
+		}).Error("GetByTarget failed: invalid pagination parameters")
+		return pagination.Page[*model.Like]{}, err
 	}
 
-	likes, err := s.repo.GetByPostID(ctx, postID, limit, offset)
+	likes, err := s.repo.GetByTarget(ctx, t, viewerID, p.Fetch())
 	if err != nil {
-		s.logger.WithField("post_id", postID.String()).WithError(err).Error("GetByPostID failed")
-		return nil, err
+		s.logger.WithField("target", t.String()).WithError(err).Error("GetByTarget failed")
+		return pagination.Page[*model.Like]{}, err
 	}
-	return likes, nil
+	return pagination.NewPage(likes, p.Limit, likeCursor), nil
 }
 
-// GetByUserID retrieves all likes by a given user ID with pagination.
-// Returns an error if the user ID is empty or no likes are found.
-func (s *LikeService) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*model.Like, error) {
+// GetByUserID returns one page of likes by a user, newest first.
+// Returns an error if the user ID is empty, the pagination parameters are invalid,
+// or ErrBlocked if the user and the viewer are in a block.
+func (s *LikeService) GetByUserID(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) (pagination.Page[*model.Like], error) {
 	if userID == uuid.Nil {
 		s.logger.Error("GetByUserID failed: empty user ID")
-		return nil, errors.New("user ID cannot be empty")
+		return pagination.Page[*model.Like]{}, errors.New("user ID cannot be empty")
 	}
-	if limit <= 0 || offset < 0 {
+	if err := p.Validate(); err != nil {
 		s.logger.WithFields(logrus.Fields{
-			"limit":  limit,
-			"offset": offset,
+			"limit":  p.Limit,
+			"offset": p.Offset,
 		}).Error("GetByUserID failed: invalid pagination parameters")
-		return nil, errors.New("invalid pagination parameters")
+		return pagination.Page[*model.Like]{}, err
 	}
 
-	likes, err := s.repo.GetByUserID(ctx, userID, limit, offset)
+	if viewerID != uuid.Nil && viewerID != userID {
+		blocked, err := s.blocks.IsBlocked(ctx, viewerID, userID)
+		if err != nil {
+			return pagination.Page[*model.Like]{}, err
+		}
+		if blocked {
+			return pagination.Page[*model.Like]{}, commonErrors.ErrBlocked
+		}
+	}
+
+	likes, err := s.repo.GetByUserID(ctx, userID, p.Fetch())
 	if err != nil {
 		s.logger.WithField("user_id", userID.String()).WithError(err).Error("GetByUserID failed")
-		return nil, err
+		return pagination.Page[*model.Like]{}, err
 	}
-	return likes, nil
+	return pagination.NewPage(likes, p.Limit, likeCursor), nil
+}
+
+func likeCursor(l *model.Like) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: l.CreatedAt, ID: l.ID}
 }
 
 // Delete soft-deletes a like by its ID.
@@ -126,10 +224,313 @@ func (s *LikeService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID
 		return errors.New("ID cannot be empty")
 	}
 
-	if err := s.repo.Delete(ctx, id, userID); err != nil {
+	like, err := s.repo.Delete(ctx, id, userID)
+	if err != nil {
 		s.logger.WithField("id", id.String()).WithError(err).Error("Unlike failed")
 		return err
 	}
+	s.invalidateCount(ctx, like.Target())
+	s.storeStatus(ctx, userID, like.Target(), notLikedMarker)
+	s.recordTrending(ctx, like, -1)
 	s.logger.WithField("id", id.String()).Info("Like deleted")
 	return nil
 }
+
+// Unlike soft-deletes the user's like on a target.
+// Returns ErrNotFound if the target is not liked by the user.
+func (s *LikeService) Unlike(ctx context.Context, userID uuid.UUID, t model.Target) error {
+	if userID == uuid.Nil {
+		s.logger.Error("Unlike failed: empty ID")
+		return errors.New("ID cannot be empty")
+	}
+	if err := validateTarget(t); err != nil {
+		s.logger.WithField("target", t.String()).Error("Unlike failed: invalid target")
+		return err
+	}
+
+	like, err := s.repo.DeleteByTarget(ctx, userID, t)
+	if err != nil {
+		s.logger.WithField("target", t.String()).WithError(err).Error("Unlike failed")
+		return err
+	}
+	s.invalidateCount(ctx, t)
+	s.storeStatus(ctx, userID, t, notLikedMarker)
+	s.recordTrending(ctx, like, -1)
+	s.logger.WithField("target", t.String()).Info("Like deleted")
+	return nil
+}
+
+// GetCount returns the number of likes on a target, reading through the cache.
+func (s *LikeService) GetCount(ctx context.Context, t model.Target) (int64, error) {
+	if err := validateTarget(t); err != nil {
+		s.logger.WithField("target", t.String()).Error("GetCount failed: invalid target")
+		return 0, err
+	}
+
+	if count, ok := s.cachedCount(ctx, t); ok {
+		return count, nil
+	}
+
+	count, err := s.repo.CountByTarget(ctx, t)
+	if err != nil {
+		s.logger.WithField("target", t.String()).WithError(err).Error("GetCount failed")
+		return 0, err
+	}
+
+	s.cacheCount(ctx, t, count)
+	return count, nil
+}
+
+// GetCounts returns like counts for many targets of one type, loading cache misses with a single query.
+func (s *LikeService) GetCounts(ctx context.Context, targetType string, ids []uuid.UUID) (map[uuid.UUID]int64, error) {
+	if !model.IsTargetType(targetType) {
+		s.logger.WithField("target_type", targetType).Error("GetCounts failed: unknown target type")
+		return nil, fmt.Errorf("%w: %s", commonErrors.ErrUnknownTargetType, targetType)
+	}
+	if len(ids) == 0 || len(ids) > MaxBulkIDs {
+		s.logger.WithField("count", len(ids)).Error("GetCounts failed: invalid number of IDs")
+		return nil, fmt.Errorf("%w: between 1 and %d IDs are required", commonErrors.ErrInvalidArgument, MaxBulkIDs)
+	}
+
+	counts := make(map[uuid.UUID]int64, len(ids))
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if count, ok := s.cachedCount(ctx, model.Target{Type: targetType, ID: id}); ok {
+			counts[id] = count
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	if len(missing) == 0 {
+		return counts, nil
+	}
+
+	loaded, err := s.repo.CountByTargets(ctx, targetType, missing)
+	if err != nil {
+		s.logger.WithError(err).Error("GetCounts failed")
+		return nil, err
+	}
+	for id, count := range loaded {
+		counts[id] = count
+		s.cacheCount(ctx, model.Target{Type: targetType, ID: id}, count)
+	}
+
+	return counts, nil
+}
+
+// ReconcileCounts fixes drifted like counters and evicts their cached values.
+func (s *LikeService) ReconcileCounts(ctx context.Context) (int, error) {
+	fixed, err := s.repo.ReconcileCounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, t := range fixed {
+		s.invalidateCount(ctx, t)
+	}
+	return len(fixed), nil
+}
+
+// cachedCount is the read side of the cache-aside pattern; any cache failure counts as a miss.
+func (s *LikeService) cachedCount(ctx context.Context, t model.Target) (int64, bool) {
+	val, err := s.cache.Get(ctx, likeCountKeyPrefix+t.String())
+	if err != nil || val == "" || val == staleCountMarker {
+		return 0, false
+	}
+
+	count, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		s.logger.WithField("target", t.String()).Warn("Ignoring malformed cached like count")
+		return 0, false
+	}
+	return count, true
+}
+
+// cacheCount only fills an empty key, so a count read before a concurrent change can't replace that change's stale marker.
+func (s *LikeService) cacheCount(ctx context.Context, t model.Target, count int64) {
+	if _, err := s.cache.SetNX(ctx, likeCountKeyPrefix+t.String(), count, likeCountTTL); err != nil {
+		s.logger.WithField("target", t.String()).WithError(err).Warn("Failed to cache like count")
+	}
+}
+
+// invalidateCount marks the cached count stale instead of deleting it; see cacheCount.
+func (s *LikeService) invalidateCount(ctx context.Context, t model.Target) {
+	if err := s.cache.Set(ctx, likeCountKeyPrefix+t.String(), staleCountMarker, staleCountTTL); err != nil {
+		s.logger.WithField("target", t.String()).WithError(err).Warn("Failed to evict cached like count")
+	}
+}
+
+// GetStatuses reports, for each target of one type, whether the user has liked it.
+// Answers are cached per user and target type in a Redis hash of target ID -> like ID; only cache misses reach the database.
+func (s *LikeService) GetStatuses(ctx context.Context, userID uuid.UUID, targetType string, ids []uuid.UUID) (map[uuid.UUID]model.LikeStatus, error) {
+	if userID == uuid.Nil {
+		s.logger.Error("GetStatuses failed: empty user ID")
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if !model.IsTargetType(targetType) {
+		s.logger.WithField("target_type", targetType).Error("GetStatuses failed: unknown target type")
+		return nil, fmt.Errorf("%w: %s", commonErrors.ErrUnknownTargetType, targetType)
+	}
+	if len(ids) == 0 || len(ids) > MaxBulkIDs {
+		s.logger.WithField("count", len(ids)).Error("GetStatuses failed: invalid number of IDs")
+		return nil, fmt.Errorf("%w: between 1 and %d IDs are required", commonErrors.ErrInvalidArgument, MaxBulkIDs)
+	}
+
+	statuses := make(map[uuid.UUID]model.LikeStatus, len(ids))
+	missing := s.cachedStatuses(ctx, userID, targetType, ids, statuses)
+	if len(missing) == 0 {
+		return statuses, nil
+	}
+
+	liked, err := s.repo.GetLikedTargetIDs(ctx, userID, targetType, missing)
+	if err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Error("GetStatuses failed")
+		return nil, err
+	}
+
+	for _, id := range missing {
+		if likeID, ok := liked[id]; ok {
+			statuses[id] = model.LikeStatus{Liked: true, LikeID: &likeID}
+		} else {
+			statuses[id] = model.LikeStatus{Liked: false}
+		}
+	}
+	s.cacheStatuses(ctx, userID, targetType, missing, liked)
+
+	return statuses, nil
+}
+
+func likedKey(userID uuid.UUID, targetType string) string {
+	return likedKeyPrefix + targetType + ":" + userID.String()
+}
+
+// cachedStatuses fills statuses from the per-user hash and returns the target IDs it couldn't answer.
+func (s *LikeService) cachedStatuses(ctx context.Context, userID uuid.UUID, targetType string, ids []uuid.UUID, statuses map[uuid.UUID]model.LikeStatus) []uuid.UUID {
+	if s.statusTTL <= 0 {
+		return ids
+	}
+
+	fields := make([]string, len(ids))
+	for i, id := range ids {
+		fields[i] = id.String()
+	}
+
+	cached, err := s.cache.HMGet(ctx, likedKey(userID, targetType), fields...)
+	if err != nil {
+		return ids
+	}
+
+	var missing []uuid.UUID
+	for _, id := range ids {
+		val, ok := cached[id.String()]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		if val == notLikedMarker {
+			statuses[id] = model.LikeStatus{Liked: false}
+			continue
+		}
+		likeID, err := uuid.Parse(val)
+		if err != nil {
+			missing = append(missing, id)
+			continue
+		}
+		statuses[id] = model.LikeStatus{Liked: true, LikeID: &likeID}
+	}
+	return missing
+}
+
+// cacheStatuses only fills fields that are absent, so answers read before a concurrent like or unlike
+// can't overwrite what storeStatus wrote for it.
+func (s *LikeService) cacheStatuses(ctx context.Context, userID uuid.UUID, targetType string, ids []uuid.UUID, liked map[uuid.UUID]uuid.UUID) {
+	if s.statusTTL <= 0 {
+		return
+	}
+
+	values := make(map[string]interface{}, len(ids))
+	for _, id := range ids {
+		if likeID, ok := liked[id]; ok {
+			values[id.String()] = likeID.String()
+		} else {
+			values[id.String()] = notLikedMarker
+		}
+	}
+
+	key := likedKey(userID, targetType)
+	if err := s.cache.HSetNX(ctx, key, values); err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to cache like statuses")
+		return
+	}
+	if err := s.cache.Expire(ctx, key, s.statusTTL); err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to set like status TTL")
+	}
+}
+
+// storeStatus writes a committed like (its ID) or unlike (notLikedMarker) through to the status cache.
+func (s *LikeService) storeStatus(ctx context.Context, userID uuid.UUID, t model.Target, value string) {
+	if s.statusTTL <= 0 {
+		return
+	}
+
+	key := likedKey(userID, t.Type)
+	if err := s.cache.HSet(ctx, key, map[string]interface{}{t.ID.String(): value}); err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to update cached like status")
+		// A stale answer is worse than none.
+		if err := s.cache.HDel(ctx, key, t.ID.String()); err != nil {
+			s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to evict cached like status")
+		}
+		return
+	}
+	if err := s.cache.Expire(ctx, key, s.statusTTL); err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to set like status TTL")
+	}
+}
+
+// GetReactionCounts returns the number of likes on a target per reaction type.
+func (s *LikeService) GetReactionCounts(ctx context.Context, t model.Target) (map[string]int64, error) {
+	if err := validateTarget(t); err != nil {
+		s.logger.WithField("target", t.String()).Error("GetReactionCounts failed: invalid target")
+		return nil, err
+	}
+
+	counts, err := s.repo.CountReactionsByTarget(ctx, t)
+	if err != nil {
+		s.logger.WithField("target", t.String()).WithError(err).Error("GetReactionCounts failed")
+		return nil, err
+	}
+	return counts, nil
+}
+
+// recordTrending moves a post's trending score; likes on other targets don't trend.
+func (s *LikeService) recordTrending(ctx context.Context, like *model.Like, delta float64) {
+	if s.trending == nil || like.TargetType != model.TargetPost {
+		return
+	}
+	s.trending.RecordLike(ctx, like.TargetID, like.CreatedAt, delta)
+}
+
+// validateTarget rejects empty IDs and types outside model.TargetTypes.
+func validateTarget(t model.Target) error {
+	if !model.IsTargetType(t.Type) {
+		return fmt.Errorf("%w: %s", commonErrors.ErrUnknownTargetType, t.Type)
+	}
+	if t.ID == uuid.Nil {
+		return errors.New("target ID cannot be empty")
+	}
+	return nil
+}
+
+// normalizeReaction rejects types that aren't enabled. An empty reaction stays empty, so the repository
+// can tell a plain like, which keeps an existing reaction, from an explicit change of reaction.
+func (s *LikeService) normalizeReaction(reaction string) (string, error) {
+	reaction = strings.ToLower(strings.TrimSpace(reaction))
+	if reaction == "" {
+		return "", nil
+	}
+	if _, ok := s.reactions[reaction]; !ok {
+		return "", fmt.Errorf("%w: %s", commonErrors.ErrUnknownReaction, reaction)
+	}
+	return reaction, nil
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"engagementService/internal/repository"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+// ListService handles user-curated lists. Private lists are visible to their owner only and
+// look nonexistent to everyone else. Membership changes are written to the outbox by the repository.
+type ListService struct {
+	repo   repository.ListRepo
+	blocks repository.BlockRepo
+	logger *logrus.Logger
+}
+
+// NewListService creates a new ListService.
+func NewListService(repo repository.ListRepo, blocks repository.BlockRepo) *ListService {
+	return &ListService{repo: repo, blocks: blocks, logger: logging.GetLogger()}
+}
+
+// ListUpdate holds the fields of a list to change; nil fields are left as they are.
+type ListUpdate struct {
+	Name        *string
+	Description *string
+	IsPrivate   *bool
+}
+
+// Create makes a new custom list for the owner.
+func (s *ListService) Create(ctx context.Context, ownerID uuid.UUID, name, description string, isPrivate bool) (*model.UserList, error) {
+	name = strings.TrimSpace(name)
+	if ownerID == uuid.Nil || name == "" {
+		return nil, fmt.Errorf("%w: owner and name are required", commonErrors.ErrInvalidArgument)
+	}
+
+	l := &model.UserList{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Name:        name,
+		Description: description,
+		IsPrivate:   isPrivate,
+		Kind:        model.ListKindCustom,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.repo.Create(ctx, l); err != nil {
+		return nil, err
+	}
+	s.logger.WithField("list_id", l.ID.String()).Info("List created")
+	return l, nil
+}
+
+// Get returns a list the viewer is allowed to see.
+func (s *ListService) Get(ctx context.Context, listID, viewerID uuid.UUID) (*model.UserList, error) {
+	l, err := s.repo.Get(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkVisible(ctx, l, viewerID); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Update changes a custom list. Only its owner may do so; the close friends list can't be changed.
+func (s *ListService) Update(ctx context.Context, listID, ownerID uuid.UUID, u ListUpdate) (*model.UserList, error) {
+	l, err := s.owned(ctx, listID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if l.IsSystem() {
+		return nil, fmt.Errorf("%w: %s list can't be changed", commonErrors.ErrInvalidArgument, l.Kind)
+	}
+
+	if u.Name != nil {
+		name := strings.TrimSpace(*u.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", commonErrors.ErrInvalidArgument)
+		}
+		l.Name = name
+	}
+	if u.Description != nil {
+		l.Description = *u.Description
+	}
+	if u.IsPrivate != nil {
+		l.IsPrivate = *u.IsPrivate
+	}
+
+	if err := s.repo.Update(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Delete removes a custom list. Only its owner may do so.
+func (s *ListService) Delete(ctx context.Context, listID, ownerID uuid.UUID) error {
+	l, err := s.owned(ctx, listID, ownerID)
+	if err != nil {
+		return err
+	}
+	if l.IsSystem() {
+		return fmt.Errorf("%w: %s list can't be deleted", commonErrors.ErrInvalidArgument, l.Kind)
+	}
+	return s.repo.Delete(ctx, l)
+}
+
+// GetCloseFriends returns the owner's close friends list, creating it on first use.
+func (s *ListService) GetCloseFriends(ctx context.Context, ownerID uuid.UUID) (*model.UserList, error) {
+	if ownerID == uuid.Nil {
+		return nil, fmt.Errorf("%w: owner is required", commonErrors.ErrInvalidArgument)
+	}
+	return s.repo.EnsureCloseFriends(ctx, ownerID)
+}
+
+// IsCloseFriend reports whether userID is on ownerID's close friends list, e.g. for post visibility checks.
+func (s *ListService) IsCloseFriend(ctx context.Context, ownerID, userID uuid.UUID) (bool, error) {
+	if ownerID == userID {
+		return true, nil
+	}
+	return s.repo.IsCloseFriend(ctx, ownerID, userID)
+}
+
+// ListOwned returns one page of the owner's lists; viewers other than the owner only see public ones.
+func (s *ListService) ListOwned(ctx context.Context, ownerID, viewerID uuid.UUID, p pagination.Params) (pagination.Page[model.UserList], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.UserList]{}, err
+	}
+	if viewerID != ownerID {
+		if err := s.checkBlock(ctx, ownerID, viewerID); err != nil {
+			return pagination.Page[model.UserList]{}, err
+		}
+	}
+	lists, err := s.repo.ListByOwner(ctx, ownerID, viewerID == ownerID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.UserList]{}, err
+	}
+	return pagination.NewPage(lists, p.Limit, listCursor), nil
+}
+
+// ListFollowed returns one page of the lists the user follows.
+func (s *ListService) ListFollowed(ctx context.Context, userID uuid.UUID, p pagination.Params) (pagination.Page[model.UserList], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.UserList]{}, err
+	}
+	lists, err := s.repo.ListFollowed(ctx, userID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.UserList]{}, err
+	}
+	return pagination.NewPage(lists, p.Limit, followedListCursor), nil
+}
+
+// AddMember adds memberID to one of the owner's lists. Returns false if they already were a member.
+func (s *ListService) AddMember(ctx context.Context, listID, ownerID, memberID uuid.UUID) (bool, error) {
+	if memberID == uuid.Nil || memberID == ownerID {
+		return false, fmt.Errorf("%w: invalid member", commonErrors.ErrInvalidArgument)
+	}
+	l, err := s.owned(ctx, listID, ownerID)
+	if err != nil {
+		return false, err
+	}
+	if err := s.checkBlock(ctx, ownerID, memberID); err != nil {
+		return false, err
+	}
+
+	return s.repo.AddMember(ctx, l, memberID)
+}
+
+// RemoveMember removes memberID from one of the owner's lists. Returns ErrNotFound if they weren't a member.
+func (s *ListService) RemoveMember(ctx context.Context, listID, ownerID, memberID uuid.UUID) error {
+	l, err := s.owned(ctx, listID, ownerID)
+	if err != nil {
+		return err
+	}
+	return s.repo.RemoveMember(ctx, l, memberID)
+}
+
+// GetMembers returns one page of a list's members as seen by the viewer.
+func (s *ListService) GetMembers(ctx context.Context, listID, viewerID uuid.UUID, p pagination.Params) (pagination.Page[model.ListMember], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.ListMember]{}, err
+	}
+	if _, err := s.Get(ctx, listID, viewerID); err != nil {
+		return pagination.Page[model.ListMember]{}, err
+	}
+	members, err := s.repo.GetMembers(ctx, listID, viewerID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.ListMember]{}, err
+	}
+	return pagination.NewPage(members, p.Limit, listMemberCursor), nil
+}
+
+// Follow subscribes the user to someone else's public list. Returns false if they already followed it.
+func (s *ListService) Follow(ctx context.Context, listID, followerID uuid.UUID) (bool, error) {
+	l, err := s.Get(ctx, listID, followerID)
+	if err != nil {
+		return false, err
+	}
+	if l.OwnerID == followerID {
+		return false, fmt.Errorf("%w: cannot follow own list", commonErrors.ErrInvalidArgument)
+	}
+	return s.repo.Follow(ctx, listID, followerID)
+}
+
+// Unfollow unsubscribes the user from a list. Returns ErrNotFound if they didn't follow it.
+func (s *ListService) Unfollow(ctx context.Context, listID, followerID uuid.UUID) error {
+	return s.repo.Unfollow(ctx, listID, followerID)
+}
+
+// owned returns the list if ownerID owns it. Lists of others look like they don't exist,
+// so list IDs can't be probed through the write routes.
+func (s *ListService) owned(ctx context.Context, listID, ownerID uuid.UUID) (*model.UserList, error) {
+	l, err := s.repo.Get(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if l.OwnerID != ownerID {
+		return nil, commonErrors.ErrNotFound
+	}
+	return l, nil
+}
+
+// checkVisible hides private lists from everyone but their owner and returns ErrBlocked
+// when the viewer and the owner are in a block.
+func (s *ListService) checkVisible(ctx context.Context, l *model.UserList, viewerID uuid.UUID) error {
+	if l.OwnerID == viewerID {
+		return nil
+	}
+	if l.IsPrivate {
+		return commonErrors.ErrNotFound
+	}
+	return s.checkBlock(ctx, l.OwnerID, viewerID)
+}
+
+func (s *ListService) checkBlock(ctx context.Context, a, b uuid.UUID) error {
+	if b == uuid.Nil {
+		return nil
+	}
+	blocked, err := s.blocks.IsBlocked(ctx, a, b)
+	if err != nil {
+		return fmt.Errorf("check block: %w", err)
+	}
+	if blocked {
+		return commonErrors.ErrBlocked
+	}
+	return nil
+}
+
+func listCursor(l model.UserList) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: l.CreatedAt, ID: l.ID}
+}
+
+func followedListCursor(l model.UserList) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: *l.FollowedAt, ID: l.ID}
+}
+
+func listMemberCursor(m model.ListMember) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: m.AddedAt, ID: m.MemberID}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/pagination"
+	"engagementService/internal/repository"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// MuteService handles mutes. A mute doesn't touch the subscription: the muted user stays followed,
+// and consumers of mute.changed (feed, notifications) decide what to hide.
+type MuteService struct {
+	repo   repository.MuteRepo
+	logger *logrus.Logger
+}
+
+// NewMuteService creates a new MuteService.
+func NewMuteService(repo repository.MuteRepo) *MuteService {
+	return &MuteService{repo: repo, logger: logging.GetLogger()}
+}
+
+// Mute mutes mutedID for muterID. A positive duration makes the mute expire on its own;
+// muting an already muted user replaces the previous expiry.
+func (s *MuteService) Mute(ctx context.Context, muterID, mutedID uuid.UUID, duration time.Duration) (*model.Mute, error) {
+	if muterID == uuid.Nil || mutedID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user IDs cannot be empty", commonErrors.ErrInvalidArgument)
+	}
+	if muterID == mutedID {
+		return nil, fmt.Errorf("%w: cannot mute self", commonErrors.ErrInvalidArgument)
+	}
+	if duration < 0 {
+		return nil, fmt.Errorf("%w: duration cannot be negative", commonErrors.ErrInvalidArgument)
+	}
+
+	m := &model.Mute{MuterID: muterID, MutedID: mutedID, CreatedAt: time.Now().UTC()}
+	if duration > 0 {
+		expiresAt := m.CreatedAt.Add(duration)
+		m.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repo.Upsert(ctx, m); err != nil {
+		return nil, err
+	}
+	s.logger.WithField("muter_id", muterID.String()).Info("User muted")
+	return m, nil
+}
+
+// Unmute lifts a mute. Returns ErrNotFound if the user isn't muted.
+func (s *MuteService) Unmute(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	return s.repo.Delete(ctx, muterID, mutedID)
+}
+
+// List returns one page of the user's active mutes.
+func (s *MuteService) List(ctx context.Context, muterID uuid.UUID, p pagination.Params) (pagination.Page[model.Mute], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.Mute]{}, err
+	}
+	mutes, err := s.repo.List(ctx, muterID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.Mute]{}, err
+	}
+	return pagination.NewPage(mutes, p.Limit, muteCursor), nil
+}
+
+// GetMutedIDs returns everyone the user currently mutes, for services that filter on it.
+func (s *MuteService) GetMutedIDs(ctx context.Context, muterID uuid.UUID) ([]uuid.UUID, error) {
+	return s.repo.GetMutedIDs(ctx, muterID)
+}
+
+// ExpireMutes removes expired mutes in batches and returns how many were lifted.
+func (s *MuteService) ExpireMutes(ctx context.Context, batchSize int) (int, error) {
+	total := 0
+	for {
+		expired, err := s.repo.DeleteExpired(ctx, batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += len(expired)
+		if len(expired) < batchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+func muteCursor(m model.Mute) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: m.CreatedAt, ID: m.MutedID}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/repository"
+	"errors"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/caching"
+	"github.com/Sayan80bayev/go-project/pkg/events"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// Rate limited actions
+const (
+	ActionFollow       = "follow"
+	ActionLike         = "like"
+	ActionFollowImport = "follow_import" // accounts followed through bulk imports
+	ActionUnfollow     = "unfollow"      // only reported, see Record
+)
+
+// abuseFlagWindow keeps a user who stays over a limit from being reported on every refused request
+const abuseFlagWindow = 24 * time.Hour
+
+// ActionLimits caps how often a user may perform an action. Zero turns a limit off.
+type ActionLimits struct {
+	PerMinute int
+	PerDay    int
+}
+
+// RateLimitService enforces per-user limits on follows, imports and likes against spam bots, and watches
+// unfollows for follow/unfollow churn. Users who go over a limit are reported with abuse.suspected.
+type RateLimitService struct {
+	limiter caching.RateLimiter
+	outbox  repository.OutboxWriter
+	limits  map[string]ActionLimits
+	logger  *logrus.Logger
+}
+
+// NewRateLimitService creates a new RateLimitService with the limits of each action.
+func NewRateLimitService(limiter caching.RateLimiter, outbox repository.OutboxWriter, limits map[string]ActionLimits) *RateLimitService {
+	return &RateLimitService{limiter: limiter, outbox: outbox, limits: limits, logger: logging.GetLogger()}
+}
+
+// Allow records the action for the user, or returns a RateLimitError if it is over a limit.
+// If Redis can't be reached the action is let through: the limits are a guard, not a dependency.
+func (s *RateLimitService) Allow(ctx context.Context, userID uuid.UUID, action string) error {
+	return s.AllowN(ctx, userID, action, 1)
+}
+
+// AllowN is Allow for n actions at once, e.g. a batch of an import. Keep n within MaxBatch:
+// a larger n is refused with ErrInvalidArgument, even when Redis is down.
+func (s *RateLimitService) AllowN(ctx context.Context, userID uuid.UUID, action string, n int) error {
+	l, ok := s.limits[action]
+	if !ok {
+		return nil
+	}
+
+	res, err := s.limiter.AllowN(ctx, action+":"+userID.String(), n,
+		caching.RateLimit{Limit: l.PerMinute, Window: time.Minute},
+		caching.RateLimit{Limit: l.PerDay, Window: 24 * time.Hour},
+	)
+	if errors.Is(err, caching.ErrOverLimit) {
+		return fmt.Errorf("%w: %v", commonErrors.ErrInvalidArgument, err)
+	}
+	if err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Rate limit check failed, allowing")
+		return nil
+	}
+	if res.Allowed {
+		return nil
+	}
+
+	s.flag(ctx, userID, action, res.Exceeded)
+	return &commonErrors.RateLimitError{RetryAfter: res.RetryAfter}
+}
+
+// Record counts an action that must not be refused, like an unfollow, and reports the user
+// if they go over its limits.
+func (s *RateLimitService) Record(ctx context.Context, userID uuid.UUID, action string) {
+	_ = s.Allow(ctx, userID, action)
+}
+
+// MaxBatch caps n to the most actions AllowN can ever let through at once
+func (s *RateLimitService) MaxBatch(action string, n int) int {
+	l := s.limits[action]
+	for _, limit := range []int{l.PerMinute, l.PerDay} {
+		if limit > 0 && n > limit {
+			n = limit
+		}
+	}
+	return n
+}
+
+// flag publishes abuse.suspected, unless the user was already reported for the action within abuseFlagWindow.
+func (s *RateLimitService) flag(ctx context.Context, userID uuid.UUID, action string, exceeded *caching.RateLimit) {
+	log := s.logger.WithField("user_id", userID.String()).WithField("action", action)
+
+	res, err := s.limiter.Allow(ctx, "abuse_flag:"+action+":"+userID.String(), caching.RateLimit{Limit: 1, Window: abuseFlagWindow})
+	if err != nil || !res.Allowed {
+		return
+	}
+
+	err = s.outbox.Enqueue(ctx, events.TopicAbuseSuspected, events.AbuseSuspectedPayload{
+		UserID:        userID,
+		Action:        action,
+		Limit:         exceeded.Limit,
+		WindowSeconds: int64(exceeded.Window / time.Second),
+		DetectedAt:    time.Now().UTC().Unix(),
+	})
+	if err != nil {
+		log.WithError(err).Warn("Failed to report suspected abuse")
+		return
+	}
+	log.Warn("Rate limit exceeded, abuse suspected")
+}
@@ -3,110 +3,377 @@ package service
 import (
 	"context"
 	commonErrors "engagementService/internal/errors"
-	"engagementService/internal/event"
+	"engagementService/internal/pagination"
 	"engagementService/internal/repository"
 	"errors"
 	"fmt"
-	"github.com/Sayan80bayev/go-project/pkg/messaging"
-	"log"
-	"time"
 
 	"github.com/google/uuid"
 
 	"engagementService/internal/model"
 )
 
+// SubscriptionService handles follow/unfollow logic.
+// Events are written to the outbox by the repository, in the same transaction as the subscription row.
+// Follows on private accounts start as pending requests that the followee approves or rejects.
+// Every relationship change, blocks and mutes included, is appended to the relationship_events log
+// in that transaction too; the service exposes the log for admins.
 type SubscriptionService struct {
-	repo     repository.SubscriptionRepo
-	producer messaging.Producer
+	repo    repository.SubscriptionRepo
+	privacy repository.PrivacyRepo
+	blocks  repository.BlockRepo
+	mutes   repository.MuteRepo
+	stats   *FollowStatsService
+	history repository.RelationshipEventRepo
+	limits  *RateLimitService
 }
 
-func NewSubscriptionService(r repository.SubscriptionRepo, p messaging.Producer) *SubscriptionService {
+func NewSubscriptionService(r repository.SubscriptionRepo, privacy repository.PrivacyRepo, blocks repository.BlockRepo, mutes repository.MuteRepo, stats *FollowStatsService, history repository.RelationshipEventRepo, limits *RateLimitService) *SubscriptionService {
 	return &SubscriptionService{
-		repo:     r,
-		producer: p,
+		repo:    r,
+		privacy: privacy,
+		blocks:  blocks,
+		mutes:   mutes,
+		stats:   stats,
+		history: history,
+		limits:  limits,
 	}
 }
 
-func (s *SubscriptionService) Follow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+// Follow subscribes the follower to the followee, or sends a follow request when the followee is private.
+// The returned subscription's Approved field tells which one happened.
+// Returns ErrBlocked if the two users are in a block, or a RateLimitError if the follower follows too often.
+func (s *SubscriptionService) Follow(ctx context.Context, followerID, followeeID uuid.UUID) (*model.Subscription, error) {
 	if followerID == uuid.Nil || followeeID == uuid.Nil {
-		return errors.New("invalid ids")
+		return nil, errors.New("invalid ids")
 	}
 	if followerID == followeeID {
-		return errors.New("cannot follow self")
+		return nil, errors.New("cannot follow self")
+	}
+	// Only new follows and requests count towards the limit; Create reports the existing ones below.
+	exists, err := s.repo.HasSubscription(ctx, followerID, followeeID)
+	if err != nil {
+		return nil, fmt.Errorf("check subscription: %w", err)
+	}
+	if !exists {
+		if err := s.limits.Allow(ctx, followerID, ActionFollow); err != nil {
+			return nil, err
+		}
 	}
 
+	// Create decides Approved from the followee's privacy settings and refuses blocked pairs.
 	sub := &model.Subscription{
 		ID:         uuid.New(),
 		FollowerID: followerID,
 		FolloweeID: followeeID,
-		Approved:   true,
-		CreatedAt:  time.Now().UTC(),
 	}
 
-	err := s.repo.Create(ctx, sub)
-	if err != nil {
+	if err := s.repo.Create(ctx, sub); err != nil {
 		if errors.Is(err, commonErrors.ErrDuplicateSubscription) {
-
-			return commonErrors.ErrAlreadyFollowing
+			return nil, commonErrors.ErrAlreadyFollowing
 		}
-		return fmt.Errorf("repo create: %w", err)
-	}
-
-	go func() {
-		payload := events.SubscriptionCreatedPayload{
-			FollowerID: followerID,
-			FolloweeID: followeeID,
-			CreatedAt:  sub.CreatedAt.Unix(),
-		}
-		if perr := s.producer.Produce(context.Background(), events.TopicSubscriptionCreated, payload); perr != nil {
-
-			log.Printf("warn: failed to produce SubscriptionCreated event: %v", perr)
+		if errors.Is(err, commonErrors.ErrFollowRequestPending) || errors.Is(err, commonErrors.ErrBlocked) {
+			return nil, err
 		}
-	}()
+		return nil, fmt.Errorf("repo create: %w", err)
+	}
 
-	return nil
+	if sub.Approved {
+		s.stats.Invalidate(ctx, followerID, followeeID)
+	}
+	return sub, nil
 }
 
+// Unfollow ends the follower's subscription. Unfollows count towards the churn reported by RateLimitService.
 func (s *SubscriptionService) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
 	if followerID == uuid.Nil || followeeID == uuid.Nil {
 		return errors.New("invalid ids")
 	}
 
 	if err := s.repo.Delete(ctx, followerID, followeeID); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			return commonErrors.ErrNotFollowing
+		}
 		return fmt.Errorf("repo delete: %w", err)
 	}
 
-	go func() {
-		payload := events.SubscriptionDeletedPayload{
-			FollowerID: followerID,
-			FolloweeID: followeeID,
-			DeletedAt:  time.Now().UTC().Unix(),
+	// Unfollows are never refused, but mass unfollowing after mass following is churn worth reporting
+	s.limits.Record(ctx, followerID, ActionUnfollow)
+	s.stats.Invalidate(ctx, followerID, followeeID)
+	return nil
+}
+
+func (s *SubscriptionService) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	return s.repo.IsFollowing(ctx, followerID, followeeID)
+}
+
+// GetFollowers lists the user's followers as seen by viewerID: users in a block with the viewer are left out,
+// and ErrBlocked is returned if the viewer and the user themselves are in one. uuid.Nil sees everyone.
+func (s *SubscriptionService) GetFollowers(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) (pagination.Page[model.Subscription], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	if err := s.checkViewer(ctx, userID, viewerID); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	subs, err := s.repo.GetFollowers(ctx, userID, viewerID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	return pagination.NewPage(subs, p.Limit, subscriptionCursor), nil
+}
+
+// GetFollowing lists whom the user follows, filtered for viewerID like GetFollowers.
+// Users looking at their own list also see which followees they muted.
+func (s *SubscriptionService) GetFollowing(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) (pagination.Page[model.Subscription], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	if err := s.checkViewer(ctx, userID, viewerID); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	subs, err := s.repo.GetFollowing(ctx, userID, viewerID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+
+	if viewerID == userID && len(subs) > 0 {
+		ids := make([]uuid.UUID, len(subs))
+		for i, sub := range subs {
+			ids[i] = sub.FolloweeID
 		}
-		if perr := s.producer.Produce(context.Background(), events.TopicSubscriptionDeleted, payload); perr != nil {
-			log.Printf("warn: failed to produce SubscriptionDeleted event: %v", perr)
+		muted, err := s.mutes.FilterMuted(ctx, userID, ids)
+		if err != nil {
+			return pagination.Page[model.Subscription]{}, fmt.Errorf("filter muted: %w", err)
+		}
+		for i := range subs {
+			subs[i].Muted = muted[subs[i].FolloweeID]
+		}
+	}
+
+	return pagination.NewPage(subs, p.Limit, subscriptionCursor), nil
+}
+
+// KnownFollowersPage is a page of followers you know plus how many there are in total,
+// so profiles can render "Followed by A, B and 3 others" from the first page.
+type KnownFollowersPage struct {
+	pagination.Page[model.Subscription]
+	Total int64 `json:"total"`
+}
+
+// GetMutualFollowers lists the users who follow both userID and otherID, filtered for viewerID like GetFollowers.
+func (s *SubscriptionService) GetMutualFollowers(ctx context.Context, userID, otherID, viewerID uuid.UUID, p pagination.Params) (pagination.Page[model.Subscription], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	if userID == otherID {
+		return pagination.Page[model.Subscription]{}, fmt.Errorf("%w: users must differ", commonErrors.ErrInvalidArgument)
+	}
+	for _, id := range []uuid.UUID{userID, otherID} {
+		if err := s.checkViewer(ctx, id, viewerID); err != nil {
+			return pagination.Page[model.Subscription]{}, err
 		}
-	}()
+	}
+	subs, err := s.repo.GetMutualFollowers(ctx, userID, otherID, viewerID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	return pagination.NewPage(subs, p.Limit, subscriptionCursor), nil
+}
+
+// GetFollowersYouKnow lists the user's followers that viewerID follows too.
+func (s *SubscriptionService) GetFollowersYouKnow(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) (KnownFollowersPage, error) {
+	if err := p.Validate(); err != nil {
+		return KnownFollowersPage{}, err
+	}
+	if viewerID == uuid.Nil {
+		return KnownFollowersPage{}, fmt.Errorf("%w: viewer is required", commonErrors.ErrInvalidArgument)
+	}
+	if err := s.checkViewer(ctx, userID, viewerID); err != nil {
+		return KnownFollowersPage{}, err
+	}
+	subs, err := s.repo.GetFollowersYouKnow(ctx, userID, viewerID, p.Fetch())
+	if err != nil {
+		return KnownFollowersPage{}, err
+	}
+	total, err := s.repo.CountFollowersYouKnow(ctx, userID, viewerID)
+	if err != nil {
+		return KnownFollowersPage{}, err
+	}
+	return KnownFollowersPage{Page: pagination.NewPage(subs, p.Limit, subscriptionCursor), Total: total}, nil
+}
 
+// GetFriends lists the users who follow userID and are followed back, filtered for viewerID like GetFollowers.
+func (s *SubscriptionService) GetFriends(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) (pagination.Page[model.Subscription], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	if err := s.checkViewer(ctx, userID, viewerID); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	subs, err := s.repo.GetFriends(ctx, userID, viewerID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	return pagination.NewPage(subs, p.Limit, subscriptionCursor), nil
+}
+
+// checkViewer returns ErrBlocked when the viewer and the user are in a block.
+func (s *SubscriptionService) checkViewer(ctx context.Context, userID, viewerID uuid.UUID) error {
+	if viewerID == uuid.Nil || viewerID == userID {
+		return nil
+	}
+	blocked, err := s.blocks.IsBlocked(ctx, viewerID, userID)
+	if err != nil {
+		return fmt.Errorf("check block: %w", err)
+	}
+	if blocked {
+		return commonErrors.ErrBlocked
+	}
 	return nil
 }
 
-func (s *SubscriptionService) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
-	return s.repo.IsFollowing(ctx, followerID, followeeID)
+// ExportGraph walks all of the user's followers and then everyone they follow, calling fn for each,
+// a page at a time so the export doesn't hold a connection while the client reads.
+func (s *SubscriptionService) ExportGraph(ctx context.Context, userID uuid.UUID, fn func(model.GraphEdge) error) error {
+	if err := s.exportEdges(ctx, userID, model.GraphFollower, s.repo.GetFollowers, fn); err != nil {
+		return err
+	}
+	return s.exportEdges(ctx, userID, model.GraphFollowing, s.repo.GetFollowing, fn)
+}
+
+func (s *SubscriptionService) exportEdges(ctx context.Context, userID uuid.UUID, relation string,
+	list func(ctx context.Context, userID, viewerID uuid.UUID, p pagination.Params) ([]model.Subscription, error),
+	fn func(model.GraphEdge) error) error {
+	p := pagination.Params{Limit: pagination.MaxLimit}
+	for {
+		subs, err := list(ctx, userID, uuid.Nil, p)
+		if err != nil {
+			return fmt.Errorf("export %s: %w", relation, err)
+		}
+		for _, sub := range subs {
+			edge := model.GraphEdge{Relation: relation, UserID: sub.FolloweeID, Since: sub.CreatedAt}
+			if relation == model.GraphFollower {
+				edge.UserID = sub.FollowerID
+			}
+			if err := fn(edge); err != nil {
+				return err
+			}
+		}
+		if len(subs) < p.Limit {
+			return nil
+		}
+		cursor := subscriptionCursor(subs[len(subs)-1])
+		p.After = &cursor
+	}
+}
+
+func subscriptionCursor(sub model.Subscription) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: sub.CreatedAt, ID: sub.ID}
+}
+
+// GetFollowStats returns the user's follower and following counts.
+func (s *SubscriptionService) GetFollowStats(ctx context.Context, userID uuid.UUID) (model.FollowStats, error) {
+	return s.stats.Get(ctx, userID)
+}
+
+// GetFollowStatsBatch returns counts for up to MaxBulkIDs users, e.g. for a page of profile cards.
+func (s *SubscriptionService) GetFollowStatsBatch(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]model.FollowStats, error) {
+	return s.stats.GetMany(ctx, userIDs)
+}
+
+// GetPendingRequests lists the follow requests waiting for the user's answer.
+func (s *SubscriptionService) GetPendingRequests(ctx context.Context, userID uuid.UUID, p pagination.Params) (pagination.Page[model.Subscription], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	subs, err := s.repo.GetPending(ctx, userID, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.Subscription]{}, err
+	}
+	return pagination.NewPage(subs, p.Limit, subscriptionCursor), nil
+}
+
+// ApproveRequest accepts a follow request sent to followeeID.
+func (s *SubscriptionService) ApproveRequest(ctx context.Context, followeeID, followerID uuid.UUID) error {
+	if err := s.repo.Approve(ctx, followerID, followeeID); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("repo approve: %w", err)
+	}
+	s.stats.Invalidate(ctx, followerID, followeeID)
+	return nil
+}
+
+// RejectRequest declines a follow request sent to followeeID.
+func (s *SubscriptionService) RejectRequest(ctx context.Context, followeeID, followerID uuid.UUID) error {
+	return s.deletePending(ctx, followerID, followeeID, followeeID, model.RelationshipReject)
+}
+
+// CancelRequest withdraws a follow request the follower sent.
+func (s *SubscriptionService) CancelRequest(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	return s.deletePending(ctx, followerID, followeeID, followerID, model.RelationshipCancelRequest)
+}
+
+func (s *SubscriptionService) deletePending(ctx context.Context, followerID, followeeID, actorID uuid.UUID, action string) error {
+	if err := s.repo.DeletePending(ctx, followerID, followeeID, actorID, action); err != nil {
+		if errors.Is(err, commonErrors.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("repo delete pending: %w", err)
+	}
+	return nil
 }
 
-func (s *SubscriptionService) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Subscription, error) {
-	return s.repo.GetFollowers(ctx, userID, limit, offset)
+func (s *SubscriptionService) GetPrivacy(ctx context.Context, userID uuid.UUID) (*model.PrivacySettings, error) {
+	return s.privacy.Get(ctx, userID)
 }
 
-func (s *SubscriptionService) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Subscription, error) {
-	return s.repo.GetFollowing(ctx, userID, limit, offset)
+// SetPrivacy updates the user's privacy settings.
+// Going public approves every pending request, since nobody would be left to answer them.
+func (s *SubscriptionService) SetPrivacy(ctx context.Context, userID uuid.UUID, isPrivate bool) (*model.PrivacySettings, error) {
+	settings := &model.PrivacySettings{UserID: userID, IsPrivate: isPrivate}
+	if err := s.privacy.Upsert(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	if !isPrivate {
+		followerIDs, err := s.repo.ApproveAllPending(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("approve pending requests: %w", err)
+		}
+		if len(followerIDs) > 0 {
+			s.stats.Invalidate(ctx, append(followerIDs, userID)...)
+		}
+	}
+	return settings, nil
 }
 
-func (s *SubscriptionService) CountFollowers(ctx context.Context, userID uuid.UUID) (int64, error) {
-	return s.repo.CountFollowers(ctx, userID)
+// GetRelationshipEvents returns one page of the user's relationship history, as actor or target, newest first.
+func (s *SubscriptionService) GetRelationshipEvents(ctx context.Context, q model.RelationshipEventQuery, p pagination.Params) (pagination.Page[model.RelationshipEvent], error) {
+	if err := p.Validate(); err != nil {
+		return pagination.Page[model.RelationshipEvent]{}, err
+	}
+	if q.UserID == uuid.Nil {
+		return pagination.Page[model.RelationshipEvent]{}, fmt.Errorf("%w: user id is required", commonErrors.ErrInvalidArgument)
+	}
+	if !q.From.Before(q.To) {
+		return pagination.Page[model.RelationshipEvent]{}, fmt.Errorf("%w: from must be before to", commonErrors.ErrInvalidArgument)
+	}
+	for _, a := range q.Actions {
+		if !model.IsRelationshipAction(a) {
+			return pagination.Page[model.RelationshipEvent]{}, fmt.Errorf("%w: unknown action %q", commonErrors.ErrInvalidArgument, a)
+		}
+	}
+
+	evs, err := s.history.List(ctx, q, p.Fetch())
+	if err != nil {
+		return pagination.Page[model.RelationshipEvent]{}, err
+	}
+	return pagination.NewPage(evs, p.Limit, relationshipEventCursor), nil
 }
 
-func (s *SubscriptionService) CountFollowing(ctx context.Context, userID uuid.UUID) (int64, error) {
-	return s.repo.CountFollowing(ctx, userID)
+func relationshipEventCursor(e model.RelationshipEvent) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: e.CreatedAt, ID: e.ID}
 }
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/repository"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/caching"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"time"
+)
+
+const (
+	suggestionKeyPrefix = "suggestions:"
+	suggestionPoolKey   = suggestionKeyPrefix + "pool"
+	MaxSuggestionLimit  = 50
+
+	// Candidates fetched per source before scoring
+	friendsOfFriendsLimit = 200
+	suggestionPoolSize    = 200
+
+	// Weights of the sources: each mutual followee counts fully, while popular and
+	// recently active accounts add at most this much, scaled against the top account of the pool
+	mutualWeight         = 1.0
+	popularWeight        = 2.0
+	recentlyActiveWeight = 1.0
+)
+
+// SuggestionServiceConfig holds the tunables of SuggestionService.
+type SuggestionServiceConfig struct {
+	CacheTTL     time.Duration // how long a user's precomputed suggestions are served
+	ActiveWindow time.Duration // how far back follows count towards "recently active"
+	RefreshUsers int           // how many recently active users the refresh job precomputes for
+}
+
+// suggestionPool holds the candidates that are the same for every user. It is cached next to the
+// per-user lists, so a cache miss only has to run the friends-of-friends query.
+type suggestionPool struct {
+	Popular        []model.SuggestionCandidate `json:"popular"`
+	RecentlyActive []model.SuggestionCandidate `json:"recently_active"`
+}
+
+// SuggestionService ranks "who to follow" candidates from the subscriptions graph.
+// Lists are precomputed by the refresh job and cached per user; anything the user followed,
+// blocked or dismissed since then is filtered out when the list is read.
+type SuggestionService struct {
+	repo   repository.SuggestionRepo
+	cache  caching.CacheService
+	cfg    SuggestionServiceConfig
+	logger *logrus.Logger
+}
+
+// NewSuggestionService creates a new SuggestionService.
+func NewSuggestionService(repo repository.SuggestionRepo, cache caching.CacheService, cfg SuggestionServiceConfig) *SuggestionService {
+	return &SuggestionService{repo: repo, cache: cache, cfg: cfg, logger: logging.GetLogger()}
+}
+
+// Get returns up to limit suggestions for the user, computing them if none are cached.
+func (s *SuggestionService) Get(ctx context.Context, userID uuid.UUID, limit int) ([]model.Suggestion, error) {
+	if limit <= 0 || limit > MaxSuggestionLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", commonErrors.ErrInvalidArgument, MaxSuggestionLimit)
+	}
+
+	suggestions, ok := s.cached(ctx, userID)
+	if !ok {
+		pool, err := s.pool(ctx)
+		if err != nil {
+			return nil, err
+		}
+		suggestions, err = s.compute(ctx, userID, pool)
+		if err != nil {
+			return nil, err
+		}
+		s.store(ctx, userID, suggestions)
+	}
+
+	ids := make([]uuid.UUID, len(suggestions))
+	for i, sug := range suggestions {
+		ids[i] = sug.UserID
+	}
+	excluded, err := s.repo.FilterExcluded(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]model.Suggestion, 0, limit)
+	for _, sug := range suggestions {
+		if excluded[sug.UserID] {
+			continue
+		}
+		out = append(out, sug)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Dismiss hides dismissedID from the user's suggestions for good.
+func (s *SuggestionService) Dismiss(ctx context.Context, userID, dismissedID uuid.UUID) error {
+	if userID == uuid.Nil || dismissedID == uuid.Nil {
+		return fmt.Errorf("%w: user IDs cannot be empty", commonErrors.ErrInvalidArgument)
+	}
+	if err := s.repo.Dismiss(ctx, userID, dismissedID); err != nil {
+		return err
+	}
+
+	// Reads filter dismissals anyway; dropping it here just keeps the cached list from shrinking below the limit
+	if suggestions, ok := s.cached(ctx, userID); ok {
+		kept := suggestions[:0]
+		for _, sug := range suggestions {
+			if sug.UserID != dismissedID {
+				kept = append(kept, sug)
+			}
+		}
+		s.store(ctx, userID, kept)
+	}
+	return nil
+}
+
+// RefreshActive recomputes the shared pool and the suggestions of recently active users.
+// Returns how many users were refreshed.
+func (s *SuggestionService) RefreshActive(ctx context.Context) (int, error) {
+	pool, err := s.loadPool(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	users, err := s.repo.RecentlyActive(ctx, time.Now().UTC().Add(-s.cfg.ActiveWindow), s.cfg.RefreshUsers)
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	for _, u := range users {
+		if ctx.Err() != nil {
+			return refreshed, ctx.Err()
+		}
+		suggestions, err := s.compute(ctx, u.UserID, pool)
+		if err != nil {
+			s.logger.WithField("user_id", u.UserID.String()).WithError(err).Warn("Failed to compute suggestions")
+			continue
+		}
+		s.store(ctx, u.UserID, suggestions)
+		refreshed++
+	}
+	return refreshed, nil
+}
+
+// compute scores every candidate for the user and keeps the best MaxSuggestionLimit.
+func (s *SuggestionService) compute(ctx context.Context, userID uuid.UUID, pool *suggestionPool) ([]model.Suggestion, error) {
+	fof, err := s.repo.FriendsOfFriends(ctx, userID, friendsOfFriendsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := make(map[uuid.UUID]*model.Suggestion)
+	best := make(map[uuid.UUID]float64) // largest single contribution, which names the reason
+	add := func(id uuid.UUID, score float64, reason string) *model.Suggestion {
+		sug, ok := scored[id]
+		if !ok {
+			sug = &model.Suggestion{UserID: id}
+			scored[id] = sug
+		}
+		sug.Score += score
+		if score > best[id] {
+			best[id] = score
+			sug.Reason = reason
+		}
+		return sug
+	}
+
+	for _, c := range fof {
+		add(c.UserID, mutualWeight*float64(c.Count), model.SuggestionFriendsOfFriends).MutualCount = c.Count
+	}
+
+	// Pool candidates were not filtered for this user yet
+	var poolIDs []uuid.UUID
+	for _, c := range pool.Popular {
+		poolIDs = append(poolIDs, c.UserID)
+	}
+	for _, c := range pool.RecentlyActive {
+		poolIDs = append(poolIDs, c.UserID)
+	}
+	excluded, err := s.repo.FilterExcluded(ctx, userID, poolIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range scaled(pool.Popular, popularWeight) {
+		if !excluded[c.id] {
+			add(c.id, c.score, model.SuggestionPopular)
+		}
+	}
+	for _, c := range scaled(pool.RecentlyActive, recentlyActiveWeight) {
+		if !excluded[c.id] {
+			add(c.id, c.score, model.SuggestionRecentlyActive)
+		}
+	}
+
+	suggestions := make([]model.Suggestion, 0, len(scored))
+	for _, sug := range scored {
+		suggestions = append(suggestions, *sug)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].UserID.String() < suggestions[j].UserID.String()
+	})
+	if len(suggestions) > MaxSuggestionLimit {
+		suggestions = suggestions[:MaxSuggestionLimit]
+	}
+	return suggestions, nil
+}
+
+type scaledCandidate struct {
+	id    uuid.UUID
+	score float64
+}
+
+// scaled maps candidate counts onto (0, weight], relative to the largest count of the list.
+func scaled(candidates []model.SuggestionCandidate, weight float64) []scaledCandidate {
+	var max int64
+	for _, c := range candidates {
+		if c.Count > max {
+			max = c.Count
+		}
+	}
+	if max == 0 {
+		return nil
+	}
+
+	out := make([]scaledCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		out = append(out, scaledCandidate{id: c.UserID, score: weight * float64(c.Count) / float64(max)})
+	}
+	return out
+}
+
+// pool returns the cached shared candidates, loading them if the cache has none.
+func (s *SuggestionService) pool(ctx context.Context) (*suggestionPool, error) {
+	val, err := s.cache.Get(ctx, suggestionPoolKey)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to read suggestion pool from cache")
+	}
+	if val != "" {
+		pool := &suggestionPool{}
+		if err := json.Unmarshal([]byte(val), pool); err == nil {
+			return pool, nil
+		}
+	}
+	return s.loadPool(ctx)
+}
+
+// loadPool queries the shared candidates and caches them.
+func (s *SuggestionService) loadPool(ctx context.Context) (*suggestionPool, error) {
+	popular, err := s.repo.Popular(ctx, suggestionPoolSize)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.repo.RecentlyActive(ctx, time.Now().UTC().Add(-s.cfg.ActiveWindow), suggestionPoolSize)
+	if err != nil {
+		return nil, err
+	}
+	pool := &suggestionPool{Popular: popular, RecentlyActive: active}
+
+	data, err := json.Marshal(pool)
+	if err != nil {
+		return nil, fmt.Errorf("marshal suggestion pool: %w", err)
+	}
+	if err := s.cache.Set(ctx, suggestionPoolKey, data, s.cfg.CacheTTL); err != nil {
+		s.logger.WithError(err).Warn("Failed to cache suggestion pool")
+	}
+	return pool, nil
+}
+
+// cached returns the user's precomputed suggestions; ok is false on a miss or a cache error.
+func (s *SuggestionService) cached(ctx context.Context, userID uuid.UUID) ([]model.Suggestion, bool) {
+	val, err := s.cache.Get(ctx, suggestionKeyPrefix+userID.String())
+	if err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to read suggestions from cache")
+		return nil, false
+	}
+	if val == "" {
+		return nil, false
+	}
+
+	var suggestions []model.Suggestion
+	if err := json.Unmarshal([]byte(val), &suggestions); err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Discarding malformed cached suggestions")
+		return nil, false
+	}
+	return suggestions, true
+}
+
+// store caches the user's suggestions. Failures are logged: the next read recomputes them.
+func (s *SuggestionService) store(ctx context.Context, userID uuid.UUID, suggestions []model.Suggestion) {
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to marshal suggestions")
+		return
+	}
+	if err := s.cache.Set(ctx, suggestionKeyPrefix+userID.String(), data, s.cfg.CacheTTL); err != nil {
+		s.logger.WithField("user_id", userID.String()).WithError(err).Warn("Failed to cache suggestions")
+	}
+}
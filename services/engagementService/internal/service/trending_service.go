@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	commonErrors "engagementService/internal/errors"
+	"engagementService/internal/model"
+	"engagementService/internal/repository"
+	"fmt"
+	"github.com/Sayan80bayev/go-project/pkg/caching"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	trendingKeyPrefix = "trending:"
+	trendingTopTTL    = time.Minute
+	MaxTrendingLimit  = 100
+)
+
+// trendingWindow describes one sliding window. Likes are counted in buckets of Bucket width,
+// and each bucket is weighted by 0.5^(age/HalfLife) when the window is read.
+type trendingWindow struct {
+	Length   time.Duration
+	Bucket   time.Duration
+	HalfLife time.Duration
+}
+
+var trendingWindows = map[string]trendingWindow{
+	"1h":  {Length: time.Hour, Bucket: 5 * time.Minute, HalfLife: 30 * time.Minute},
+	"24h": {Length: 24 * time.Hour, Bucket: time.Hour, HalfLife: 6 * time.Hour},
+	"7d":  {Length: 7 * 24 * time.Hour, Bucket: 6 * time.Hour, HalfLife: 2 * 24 * time.Hour},
+}
+
+// DefaultTrendingWindow is used when a client doesn't ask for one
+const DefaultTrendingWindow = "24h"
+
+// TrendingService scores posts by recent like velocity in Redis sorted sets.
+// Every window keeps one sorted set per time bucket; reads sum the buckets with decaying weights.
+type TrendingService struct {
+	repo   repository.LikeRepo
+	cache  caching.CacheService
+	logger *logrus.Logger
+}
+
+// NewTrendingService creates a new TrendingService.
+func NewTrendingService(repo repository.LikeRepo, cache caching.CacheService) *TrendingService {
+	return &TrendingService{repo: repo, cache: cache, logger: logging.GetLogger()}
+}
+
+// RecordLike adds delta to the post's bucket for the time the like was created, in every window.
+// Failures are logged and swallowed: trending is best-effort and the backfill job repairs it.
+func (s *TrendingService) RecordLike(ctx context.Context, postID uuid.UUID, likedAt time.Time, delta float64) {
+	now := time.Now()
+	for name, w := range trendingWindows {
+		if now.Sub(likedAt) > w.Length {
+			continue
+		}
+
+		key := bucketKey(name, likedAt.Truncate(w.Bucket))
+		if err := s.cache.ZIncrBy(ctx, key, delta, postID.String()); err != nil {
+			s.logger.WithField("post_id", postID.String()).WithError(err).Warn("Failed to update trending score")
+			continue
+		}
+		if err := s.cache.Expire(ctx, key, w.Length+w.Bucket); err != nil {
+			s.logger.WithField("key", key).WithError(err).Warn("Failed to set trending bucket TTL")
+		}
+	}
+}
+
+// GetTrending returns the highest-scoring posts of a window.
+// The decayed union is cached for a minute, so scores move in small steps rather than on every request.
+func (s *TrendingService) GetTrending(ctx context.Context, window string, limit int) ([]model.TrendingPost, error) {
+	w, ok := trendingWindows[window]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown window %q", commonErrors.ErrInvalidArgument, window)
+	}
+	if limit <= 0 || limit > MaxTrendingLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", commonErrors.ErrInvalidArgument, MaxTrendingLimit)
+	}
+
+	topKey := trendingKeyPrefix + window + ":top"
+	exists, err := s.cache.Exists(ctx, topKey)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		keys, weights := w.buckets(window, time.Now())
+		if err := s.cache.ZUnionStore(ctx, topKey, keys, weights); err != nil {
+			s.logger.WithField("window", window).WithError(err).Error("GetTrending failed: union buckets")
+			return nil, err
+		}
+		if err := s.cache.Expire(ctx, topKey, trendingTopTTL); err != nil {
+			s.logger.WithField("window", window).WithError(err).Warn("Failed to set trending TTL")
+		}
+	}
+
+	members, err := s.cache.ZRevRangeWithScores(ctx, topKey, 0, int64(limit-1))
+	if err != nil {
+		s.logger.WithField("window", window).WithError(err).Error("GetTrending failed")
+		return nil, err
+	}
+
+	posts := make([]model.TrendingPost, 0, len(members))
+	for _, m := range members {
+		if m.Score <= 0 {
+			break // only unliked posts remain
+		}
+		id, err := uuid.Parse(fmt.Sprint(m.Member))
+		if err != nil {
+			continue
+		}
+		posts = append(posts, model.TrendingPost{PostID: id, Score: m.Score})
+	}
+	return posts, nil
+}
+
+// Rebuild recomputes every window's buckets from the likes table.
+// Likes that land while a bucket is being rewritten may be lost from it until the next rebuild.
+func (s *TrendingService) Rebuild(ctx context.Context) error {
+	now := time.Now()
+	for name, w := range trendingWindows {
+		rows, err := s.repo.CountCreatedSince(ctx, model.TargetPost, now.Add(-w.Length).Truncate(w.Bucket), w.Bucket)
+		if err != nil {
+			return fmt.Errorf("rebuild %s: %w", name, err)
+		}
+
+		byBucket := make(map[time.Time][]redis.Z)
+		for _, r := range rows {
+			start := r.Start.Truncate(w.Bucket)
+			byBucket[start] = append(byBucket[start], redis.Z{Score: float64(r.Count), Member: r.TargetID.String()})
+		}
+
+		keys, _ := w.buckets(name, now)
+		for _, key := range keys {
+			if err := s.cache.Delete(ctx, key); err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+		}
+		for start, members := range byBucket {
+			key := bucketKey(name, start)
+			if err := s.cache.ZAdd(ctx, key, members...); err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+			if err := s.cache.Expire(ctx, key, w.Length+w.Bucket); err != nil {
+				return fmt.Errorf("rebuild %s: %w", name, err)
+			}
+		}
+		if err := s.cache.Delete(ctx, trendingKeyPrefix+name+":top"); err != nil {
+			return fmt.Errorf("rebuild %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// buckets returns the keys of the buckets that overlap the window ending at now, with their decay weights.
+func (w trendingWindow) buckets(name string, now time.Time) ([]string, []float64) {
+	var keys []string
+	var weights []float64
+	for start := now.Add(-w.Length).Truncate(w.Bucket); !start.After(now); start = start.Add(w.Bucket) {
+		age := now.Sub(start.Add(w.Bucket / 2))
+		if age < 0 {
+			age = 0
+		}
+		keys = append(keys, bucketKey(name, start))
+		weights = append(weights, math.Pow(0.5, age.Hours()/w.HalfLife.Hours()))
+	}
+	return keys, weights
+}
+
+func bucketKey(window string, start time.Time) string {
+	return trendingKeyPrefix + window + ":" + strconv.FormatInt(start.Unix(), 10)
+}
@@ -0,0 +1,50 @@
+package request
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"strings"
+)
+
+// FollowImportRequest is the JSON form of a bulk follow import.
+type FollowImportRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"required,min=1"`
+}
+
+// ParseUserIDsCSV reads the CSV form of a bulk follow import: a user ID in the first column of every
+// record. A header row is allowed; other columns and blank lines are ignored.
+func ParseUserIDsCSV(r io.Reader) ([]uuid.UUID, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var ids []uuid.UUID
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		field := strings.TrimSpace(record[0])
+		if field == "" {
+			continue
+		}
+		id, err := uuid.Parse(field)
+		if err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("invalid user id on line %d", line)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("no user ids")
+	}
+	return ids, nil
+}
@@ -3,6 +3,15 @@ package request
 import "github.com/google/uuid"
 
 type LikeRequest struct {
-	PostID uuid.UUID `json:"post_id" validate:"required,uuid"`
-	UserID uuid.UUID `json:"user_id" validate:"required,uuid"`
+	// The target comes from the URL, never from the body
+	TargetType string    `json:"-"`
+	TargetID   uuid.UUID `json:"-"`
+	UserID     uuid.UUID `json:"user_id" validate:"required,uuid"`
+	// Reaction defaults to "like" when empty, which keeps older clients working.
+	// An empty reaction never changes the reaction of an existing like.
+	Reaction string `json:"reaction"`
+}
+
+type ReactionRequest struct {
+	Reaction string `json:"reaction" binding:"required"`
 }
@@ -0,0 +1,14 @@
+package request
+
+type CreateListRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	IsPrivate   bool   `json:"is_private"`
+}
+
+// UpdateListRequest changes only the fields that are present
+type UpdateListRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	IsPrivate   *bool   `json:"is_private"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type MuteRequest struct {
+	// DurationSeconds makes the mute expire on its own; zero or absent mutes until unmuted.
+	// Capped at 10 years, which also keeps it from overflowing time.Duration.
+	DurationSeconds int64 `json:"duration_seconds" binding:"min=0,max=315360000"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type PrivacyRequest struct {
+	IsPrivate *bool `json:"is_private" binding:"required"`
+}
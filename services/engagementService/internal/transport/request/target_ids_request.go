@@ -0,0 +1,18 @@
+package request
+
+import "github.com/google/uuid"
+
+// TargetIDsRequest carries a batch of IDs of one target type for bulk lookups.
+// The post-only routes from before likes were polymorphic send them as post_ids.
+type TargetIDsRequest struct {
+	TargetIDs []uuid.UUID `json:"target_ids" binding:"max=100"`
+	PostIDs   []uuid.UUID `json:"post_ids" binding:"max=100"`
+}
+
+// IDs returns whichever of the two fields the client filled in
+func (r *TargetIDsRequest) IDs() []uuid.UUID {
+	if len(r.TargetIDs) > 0 {
+		return r.TargetIDs
+	}
+	return r.PostIDs
+}
@@ -0,0 +1,8 @@
+package request
+
+import "github.com/google/uuid"
+
+// UserIDsRequest carries a batch of user IDs for bulk lookups.
+type UserIDsRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"required,min=1,max=100"`
+}
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"engagementService/internal/service"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// FollowImporter runs queued bulk follow imports. Several replicas may run it: each job is claimed by one.
+type FollowImporter struct {
+	svc      *service.FollowImportService
+	interval time.Duration
+	logger   *logrus.Logger
+}
+
+// NewFollowImporter creates a new FollowImporter.
+func NewFollowImporter(svc *service.FollowImportService, interval time.Duration) *FollowImporter {
+	return &FollowImporter{svc: svc, interval: interval, logger: logging.GetLogger()}
+}
+
+// Start polls for import jobs until the context is cancelled.
+func (i *FollowImporter) Start(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	i.logger.Infof("Follow importer started (interval=%s)", i.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			i.logger.Info("Follow importer stopped by context cancellation")
+			return
+		case <-ticker.C:
+			// Work through the queue before waiting for the next tick
+			for ctx.Err() == nil {
+				found, err := i.svc.ProcessNext(ctx)
+				if err != nil {
+					i.logger.WithError(err).Warn("Follow import failed")
+				}
+				if !found || err != nil {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"engagementService/internal/service"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// LikeCountReconciler periodically recomputes like_counts from the likes table.
+// Counters are maintained transactionally, so this only repairs drift from manual edits or races.
+type LikeCountReconciler struct {
+	svc      *service.LikeService
+	interval time.Duration
+	logger   *logrus.Logger
+}
+
+// NewLikeCountReconciler creates a new LikeCountReconciler.
+func NewLikeCountReconciler(svc *service.LikeService, interval time.Duration) *LikeCountReconciler {
+	return &LikeCountReconciler{svc: svc, interval: interval, logger: logging.GetLogger()}
+}
+
+// Start runs the reconciliation loop until the context is cancelled.
+func (r *LikeCountReconciler) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.logger.Infof("Like count reconciler started (interval=%s)", r.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Like count reconciler stopped by context cancellation")
+			return
+		case <-ticker.C:
+			fixed, err := r.svc.ReconcileCounts(ctx)
+			if err != nil {
+				r.logger.WithError(err).Warn("Like count reconciliation failed")
+				continue
+			}
+			if fixed > 0 {
+				r.logger.Infof("Like count reconciler: corrected %d counters", fixed)
+			}
+		}
+	}
+}
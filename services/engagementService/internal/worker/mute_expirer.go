@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"engagementService/internal/service"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const muteExpiryBatch = 500
+
+// MuteExpirer deletes timed mutes once they expire, so mute.changed is published for them too.
+// Reads already ignore expired mutes; this only cleans up and notifies.
+type MuteExpirer struct {
+	svc      *service.MuteService
+	interval time.Duration
+	logger   *logrus.Logger
+}
+
+// NewMuteExpirer creates a new MuteExpirer.
+func NewMuteExpirer(svc *service.MuteService, interval time.Duration) *MuteExpirer {
+	return &MuteExpirer{svc: svc, interval: interval, logger: logging.GetLogger()}
+}
+
+// Start runs the expiry loop until the context is cancelled.
+func (e *MuteExpirer) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	e.logger.Infof("Mute expirer started (interval=%s)", e.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.logger.Info("Mute expirer stopped by context cancellation")
+			return
+		case <-ticker.C:
+			n, err := e.svc.ExpireMutes(ctx, muteExpiryBatch)
+			if err != nil {
+				e.logger.WithError(err).Warn("Mute expiry failed")
+				continue
+			}
+			if n > 0 {
+				e.logger.Infof("Mute expirer: lifted %d expired mutes", n)
+			}
+		}
+	}
+}
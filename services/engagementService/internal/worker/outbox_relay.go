@@ -0,0 +1,144 @@
+package worker
+
+import (
+	"context"
+	"engagementService/internal/repository"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/Sayan80bayev/go-project/pkg/messaging"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	outboxLease      = 30 * time.Second
+	outboxBaseDelay  = time.Second
+	outboxMaxDelay   = 5 * time.Minute
+	outboxRetention  = 7 * 24 * time.Hour
+	outboxPurgeEvery = time.Hour
+)
+
+// OutboxRelayConfig tunes how the relay drains the outbox table.
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+}
+
+// OutboxRelay publishes committed outbox messages through a messaging.Producer.
+// Failed deliveries are retried with exponential backoff until MaxAttempts is reached.
+type OutboxRelay struct {
+	repo     repository.OutboxRepo
+	producer messaging.Producer
+	cfg      OutboxRelayConfig
+	logger   *logrus.Logger
+}
+
+// NewOutboxRelay creates a new OutboxRelay.
+func NewOutboxRelay(repo repository.OutboxRepo, producer messaging.Producer, cfg OutboxRelayConfig) *OutboxRelay {
+	return &OutboxRelay{
+		repo:     repo,
+		producer: producer,
+		cfg:      cfg,
+		logger:   logging.GetLogger(),
+	}
+}
+
+// Start polls the outbox until the context is cancelled.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	r.logger.Infof("Outbox relay started (interval=%s, batch=%d)", r.cfg.PollInterval, r.cfg.BatchSize)
+
+	lastPurge := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Outbox relay stopped by context cancellation")
+			return
+		case <-ticker.C:
+			// Keep draining while full batches come back, so bursts don't wait for the next tick
+			for r.relayBatch(ctx) == r.cfg.BatchSize {
+				if ctx.Err() != nil {
+					break
+				}
+			}
+
+			if time.Since(lastPurge) >= outboxPurgeEvery {
+				r.purge(ctx)
+				lastPurge = time.Now()
+			}
+		}
+	}
+}
+
+// relayBatch publishes one batch of pending messages and returns how many were claimed.
+func (r *OutboxRelay) relayBatch(ctx context.Context) int {
+	messages, err := r.repo.ClaimPending(ctx, r.cfg.BatchSize, r.cfg.MaxAttempts, outboxLease)
+	if err != nil {
+		r.logger.WithError(err).Warn("Outbox relay: claim failed")
+		return 0
+	}
+
+	// Once a message fails, later ones with its key wait for it, so their order is kept.
+	// Their lease runs out and the claim holds them back until the failed one is published.
+	failedKeys := make(map[string]bool)
+	for _, m := range messages {
+		if m.PartitionKey != "" && failedKeys[m.PartitionKey] {
+			continue
+		}
+
+		// The outbox ID is the event ID, so consumers can drop the duplicates at-least-once delivery can cause
+		err := r.producer.Produce(ctx, m.EventType, m.Payload,
+			messaging.WithKey(m.PartitionKey), messaging.WithHeader("message_id", m.ID.String()),
+			messaging.WithEventID(m.ID.String()), messaging.WithOccurredAt(m.CreatedAt),
+			messaging.WithCorrelationID(m.CorrelationID))
+		if err != nil {
+			attempt := m.Attempts + 1
+			fields := logrus.Fields{"id": m.ID.String(), "event": m.EventType, "attempt": attempt}
+			if attempt >= r.cfg.MaxAttempts {
+				r.logger.WithError(err).WithFields(fields).Error("Outbox relay: giving up on message")
+			} else {
+				r.logger.WithError(err).WithFields(fields).Warn("Outbox relay: publish failed, will retry")
+			}
+
+			if merr := r.repo.MarkFailed(ctx, m.ID, err, time.Now().Add(backoff(attempt))); merr != nil {
+				r.logger.WithError(merr).Warn("Outbox relay: could not record failure")
+			}
+			if m.PartitionKey != "" {
+				failedKeys[m.PartitionKey] = true
+			}
+			continue
+		}
+
+		if err := r.repo.MarkPublished(ctx, m.ID); err != nil {
+			// The lease expires and the message is delivered again; consumers must tolerate duplicates
+			r.logger.WithError(err).WithField("id", m.ID.String()).Warn("Outbox relay: could not mark published")
+		}
+	}
+
+	return len(messages)
+}
+
+func (r *OutboxRelay) purge(ctx context.Context) {
+	n, err := r.repo.PurgePublished(ctx, time.Now().Add(-outboxRetention))
+	if err != nil {
+		r.logger.WithError(err).Warn("Outbox relay: purge failed")
+		return
+	}
+	if n > 0 {
+		r.logger.Infof("Outbox relay: purged %d published messages", n)
+	}
+}
+
+// backoff returns the delay before the given attempt, doubling from outboxBaseDelay up to outboxMaxDelay.
+func backoff(attempt int) time.Duration {
+	d := outboxBaseDelay
+	for i := 1; i < attempt && d < outboxMaxDelay; i++ {
+		d *= 2
+	}
+	if d > outboxMaxDelay {
+		d = outboxMaxDelay
+	}
+	return d
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"engagementService/internal/service"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// SuggestionRefresher precomputes follow suggestions for recently active users,
+// so most reads are served from the cache. Other users get theirs computed on first read.
+type SuggestionRefresher struct {
+	svc      *service.SuggestionService
+	interval time.Duration
+	logger   *logrus.Logger
+}
+
+// NewSuggestionRefresher creates a new SuggestionRefresher.
+func NewSuggestionRefresher(svc *service.SuggestionService, interval time.Duration) *SuggestionRefresher {
+	return &SuggestionRefresher{svc: svc, interval: interval, logger: logging.GetLogger()}
+}
+
+// Start runs the refresh loop until the context is cancelled.
+func (r *SuggestionRefresher) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.logger.Infof("Suggestion refresher started (interval=%s)", r.interval)
+	r.refresh(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Suggestion refresher stopped by context cancellation")
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+func (r *SuggestionRefresher) refresh(ctx context.Context) {
+	start := time.Now()
+	n, err := r.svc.RefreshActive(ctx)
+	if err != nil {
+		r.logger.WithError(err).Warn("Suggestion refresh failed")
+		return
+	}
+	r.logger.Infof("Suggestion refresher: refreshed %d users in %s", n, time.Since(start).Round(time.Millisecond))
+}
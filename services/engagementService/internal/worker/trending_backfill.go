@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"engagementService/internal/service"
+	"github.com/Sayan80bayev/go-project/pkg/logging"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// TrendingBackfill rebuilds the trending buckets from the likes table.
+// It runs once at startup, so a flushed Redis is repopulated, and then periodically to repair lost updates.
+type TrendingBackfill struct {
+	svc      *service.TrendingService
+	interval time.Duration
+	logger   *logrus.Logger
+}
+
+// NewTrendingBackfill creates a new TrendingBackfill.
+func NewTrendingBackfill(svc *service.TrendingService, interval time.Duration) *TrendingBackfill {
+	return &TrendingBackfill{svc: svc, interval: interval, logger: logging.GetLogger()}
+}
+
+// Start runs the backfill loop until the context is cancelled.
+func (b *TrendingBackfill) Start(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	b.logger.Infof("Trending backfill started (interval=%s)", b.interval)
+	b.rebuild(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			b.logger.Info("Trending backfill stopped by context cancellation")
+			return
+		case <-ticker.C:
+			b.rebuild(ctx)
+		}
+	}
+}
+
+func (b *TrendingBackfill) rebuild(ctx context.Context) {
+	start := time.Now()
+	if err := b.svc.Rebuild(ctx); err != nil {
+		b.logger.WithError(err).Warn("Trending backfill failed")
+		return
+	}
+	b.logger.Infof("Trending backfill: rebuilt scores in %s", time.Since(start).Round(time.Millisecond))
+}
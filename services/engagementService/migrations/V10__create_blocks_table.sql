@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS blocks (
+    blocker_id UUID NOT NULL,
+    blocked_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+
+-- Listings check blocks in both directions, so look them up from the blocked side too
+CREATE INDEX IF NOT EXISTS i_blocked ON blocks (blocked_id, blocker_id);
+CREATE INDEX IF NOT EXISTS i_blocker_keyset ON blocks (blocker_id, created_at DESC, blocked_id DESC);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS mutes (
+    muter_id UUID NOT NULL,
+    muted_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE, -- NULL mutes until lifted by hand
+    PRIMARY KEY (muter_id, muted_id)
+);
+
+CREATE INDEX IF NOT EXISTS i_muter_keyset ON mutes (muter_id, created_at DESC, muted_id DESC);
+CREATE INDEX IF NOT EXISTS i_mute_expires ON mutes (expires_at) WHERE expires_at IS NOT NULL;
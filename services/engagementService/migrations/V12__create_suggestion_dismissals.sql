@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS suggestion_dismissals (
+    user_id UUID NOT NULL,
+    dismissed_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, dismissed_id)
+);
+
+-- Recently active accounts are ranked by the follows they made lately
+CREATE INDEX IF NOT EXISTS i_subscriptions_created ON subscriptions (created_at) WHERE deleted_at IS NULL AND approved;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS user_follow_stats (
+    user_id UUID PRIMARY KEY,
+    followers_count BIGINT NOT NULL DEFAULT 0,
+    following_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Seed counters from subscriptions that existed before this migration
+INSERT INTO user_follow_stats (user_id, followers_count, following_count, updated_at)
+SELECT user_id, SUM(followers), SUM(following), NOW()
+FROM (
+    SELECT followee_id AS user_id, COUNT(*) AS followers, 0 AS following
+    FROM subscriptions
+    WHERE approved AND deleted_at IS NULL
+    GROUP BY followee_id
+    UNION ALL
+    SELECT follower_id AS user_id, 0 AS followers, COUNT(*) AS following
+    FROM subscriptions
+    WHERE approved AND deleted_at IS NULL
+    GROUP BY follower_id
+) counts
+GROUP BY user_id
+ON CONFLICT (user_id) DO NOTHING;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS user_lists (
+    id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    kind TEXT NOT NULL DEFAULT 'custom',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS i_list_owner_keyset ON user_lists (owner_id, created_at DESC, id DESC);
+
+-- Every user has at most one close friends list
+CREATE UNIQUE INDEX IF NOT EXISTS u_list_close_friends ON user_lists (owner_id) WHERE kind = 'close_friends';
+
+CREATE TABLE IF NOT EXISTS user_list_members (
+    list_id UUID NOT NULL REFERENCES user_lists (id) ON DELETE CASCADE,
+    member_id UUID NOT NULL,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (list_id, member_id)
+);
+
+CREATE INDEX IF NOT EXISTS i_list_member_keyset ON user_list_members (list_id, added_at DESC, member_id DESC);
+
+CREATE TABLE IF NOT EXISTS user_list_followers (
+    list_id UUID NOT NULL REFERENCES user_lists (id) ON DELETE CASCADE,
+    follower_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (list_id, follower_id)
+);
+
+CREATE INDEX IF NOT EXISTS i_list_follower_keyset ON user_list_followers (follower_id, created_at DESC, list_id DESC);
@@ -0,0 +1,11 @@
+-- Append-only history of relationship changes; rows are never updated or deleted
+CREATE TABLE IF NOT EXISTS relationship_events (
+    id UUID PRIMARY KEY,
+    actor_id UUID NOT NULL,
+    target_id UUID NOT NULL,
+    action TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS i_relationship_actor ON relationship_events (actor_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS i_relationship_target ON relationship_events (target_id, created_at DESC, id DESC);
@@ -0,0 +1,20 @@
+-- Bulk follow imports, processed in the background by the follow importer
+CREATE TABLE IF NOT EXISTS follow_import_jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    status TEXT NOT NULL,
+    user_ids UUID[] NOT NULL,
+    total INT NOT NULL,
+    processed INT NOT NULL DEFAULT 0,
+    followed INT NOT NULL DEFAULT 0,
+    requested INT NOT NULL DEFAULT 0,
+    skipped INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- One unfinished import per user
+CREATE UNIQUE INDEX IF NOT EXISTS u_follow_import_active ON follow_import_jobs (user_id) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS i_follow_import_due ON follow_import_jobs (updated_at) WHERE status IN ('pending', 'running');
@@ -0,0 +1,2 @@
+-- Entity whose events must stay in order; published as the message key
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS partition_key TEXT;
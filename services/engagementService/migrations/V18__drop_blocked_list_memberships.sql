@@ -0,0 +1,12 @@
+-- Blocks used to leave list memberships and list follows in place; remove the ones existing blocks should have
+DELETE FROM user_list_members m
+USING user_lists l, blocks b
+WHERE m.list_id = l.id
+    AND ((b.blocker_id = l.owner_id AND b.blocked_id = m.member_id)
+        OR (b.blocker_id = m.member_id AND b.blocked_id = l.owner_id));
+
+DELETE FROM user_list_followers f
+USING user_lists l, blocks b
+WHERE f.list_id = l.id
+    AND ((b.blocker_id = l.owner_id AND b.blocked_id = f.follower_id)
+        OR (b.blocker_id = f.follower_id AND b.blocked_id = l.owner_id));
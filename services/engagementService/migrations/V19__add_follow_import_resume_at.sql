@@ -0,0 +1,2 @@
+-- Imports held back by the follow import rate limit wait as pending until resume_at
+ALTER TABLE follow_import_jobs ADD COLUMN IF NOT EXISTS resume_at TIMESTAMP WITH TIME ZONE;
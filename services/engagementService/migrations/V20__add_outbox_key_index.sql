@@ -0,0 +1,2 @@
+-- Lets the relay find older unpublished messages with the same partition key
+CREATE INDEX IF NOT EXISTS i_outbox_pending_key ON outbox (partition_key, created_at) WHERE published_at IS NULL;
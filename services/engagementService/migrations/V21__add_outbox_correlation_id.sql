@@ -0,0 +1,2 @@
+-- Correlation ID of the request that produced the event, published in the event envelope
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS correlation_id TEXT;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS i_outbox_pending ON outbox (next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS i_outbox_published ON outbox (published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS like_counts (
+    post_id UUID PRIMARY KEY,
+    like_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Seed counters from likes that existed before this migration
+INSERT INTO like_counts (post_id, like_count, updated_at)
+SELECT post_id, COUNT(*), NOW()
+FROM likes
+WHERE deleted_at IS NULL
+GROUP BY post_id
+ON CONFLICT (post_id) DO NOTHING;
@@ -0,0 +1,4 @@
+-- Existing likes become the default 'like' reaction
+ALTER TABLE likes ADD COLUMN IF NOT EXISTS reaction TEXT NOT NULL DEFAULT 'like';
+
+CREATE INDEX IF NOT EXISTS i_post_reaction ON likes (post_id, reaction) WHERE deleted_at IS NULL;
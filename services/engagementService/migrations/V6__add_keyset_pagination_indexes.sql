@@ -0,0 +1,6 @@
+-- Keyset pagination walks (owner, created_at DESC, id DESC) over active rows
+CREATE INDEX IF NOT EXISTS i_like_user_keyset ON likes (user_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS i_like_post_keyset ON likes (post_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS i_followee_keyset ON subscriptions (followee_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS i_follower_keyset ON subscriptions (follower_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,22 @@
+-- Likes point at (target_type, target_id) instead of a post; every existing like is on a post
+ALTER TABLE likes RENAME COLUMN post_id TO target_id;
+ALTER TABLE likes ADD COLUMN target_type TEXT NOT NULL DEFAULT 'post';
+ALTER TABLE likes ALTER COLUMN target_type DROP DEFAULT;
+
+ALTER TABLE likes DROP CONSTRAINT IF EXISTS likes_user_id_post_id_key;
+ALTER TABLE likes ADD CONSTRAINT likes_user_target_key UNIQUE (user_id, target_type, target_id);
+
+DROP INDEX IF EXISTS i_post_id;
+DROP INDEX IF EXISTS i_post_reaction;
+DROP INDEX IF EXISTS i_like_post_keyset;
+
+CREATE INDEX IF NOT EXISTS i_target_reaction ON likes (target_type, target_id, reaction) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS i_like_target_keyset ON likes (target_type, target_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+
+-- Counters follow the same key
+ALTER TABLE like_counts RENAME COLUMN post_id TO target_id;
+ALTER TABLE like_counts ADD COLUMN target_type TEXT NOT NULL DEFAULT 'post';
+ALTER TABLE like_counts ALTER COLUMN target_type DROP DEFAULT;
+
+ALTER TABLE like_counts DROP CONSTRAINT IF EXISTS like_counts_pkey;
+ALTER TABLE like_counts ADD PRIMARY KEY (target_type, target_id);
@@ -0,0 +1,2 @@
+-- The trending backfill scans recent likes of one target type
+CREATE INDEX IF NOT EXISTS i_like_target_type_created ON likes (target_type, created_at) WHERE deleted_at IS NULL;
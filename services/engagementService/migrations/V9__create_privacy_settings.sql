@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS privacy_settings (
+    user_id UUID PRIMARY KEY,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Follower lists and counts only look at approved subscriptions
+DROP INDEX IF EXISTS i_followee_keyset;
+DROP INDEX IF EXISTS i_follower_keyset;
+CREATE INDEX IF NOT EXISTS i_followee_keyset ON subscriptions (followee_id, created_at DESC, id DESC) WHERE deleted_at IS NULL AND approved;
+CREATE INDEX IF NOT EXISTS i_follower_keyset ON subscriptions (follower_id, created_at DESC, id DESC) WHERE deleted_at IS NULL AND approved;
+
+-- Pending follow requests, listed by the account that has to answer them
+CREATE INDEX IF NOT EXISTS i_followee_pending ON subscriptions (followee_id, created_at DESC, id DESC) WHERE deleted_at IS NULL AND NOT approved;